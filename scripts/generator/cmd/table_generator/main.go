@@ -1,10 +1,12 @@
 package main
 
 import (
+	"os"
+
 	"github.com/liam/go_web_quick_start/scripts/generator/pkg/tableutil"
 )
 
 func main() {
 	// 调用表生成器
-	tableutil.GenerateTable()
+	tableutil.Run(os.Args[1:])
 }
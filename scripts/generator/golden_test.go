@@ -0,0 +1,75 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// 生成项目的模块名，testdata/golden/dao 中的测试按此导入
+const goldenModule = "example.com/golden"
+
+// TestGeneratedProject 用生成器创建SQLite项目和 posts 表，把 testdata/golden/dao 中的测试复制到
+// 生成项目的 internal/dao 后执行 go vet 和 go test，检查模板生成的DAO代码可以编译并按预期工作
+// 需要下载生成项目的依赖，-short 或依赖无法下载时跳过
+func TestGeneratedProject(t *testing.T) {
+	if testing.Short() {
+		t.Skip("-short 模式下跳过生成项目的编译测试")
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("未找到go命令")
+	}
+
+	dir := t.TempDir()
+	generator := filepath.Join(dir, "generator")
+	run(t, ".", goTool, "build", "-o", generator, ".")
+
+	root := filepath.Join(dir, "golden")
+	schema, err := filepath.Abs(filepath.Join("testdata", "golden", "posts.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	run(t, dir, generator, "--name", goldenModule, "--path", root, "--db-type", "sqlite", "--yes")
+	run(t, dir, generator, "create-table", "--schema", schema, "--root", root)
+
+	tests, err := filepath.Glob(filepath.Join("testdata", "golden", "dao", "*_test.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range tests {
+		content, err := os.ReadFile(test)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, "internal", "dao", filepath.Base(test)), content, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 只需要依赖的go.mod即可判断能否解析依赖，无法下载时跳过
+	list := command(root, goTool, "list", "-m", "all")
+	if output, err := list.CombinedOutput(); err != nil {
+		t.Skipf("无法下载生成项目的依赖: %v\n%s", err, output)
+	}
+	run(t, root, goTool, "vet", "./...")
+	run(t, root, goTool, "test", "./internal/dao/...")
+}
+
+// 在 dir 中执行命令，失败时输出命令的输出并终止测试
+func run(t *testing.T, dir, name string, args ...string) {
+	t.Helper()
+	output, err := command(dir, name, args...).CombinedOutput()
+	if err != nil {
+		t.Fatalf("%s %s 失败: %v\n%s", filepath.Base(name), strings.Join(args, " "), err, output)
+	}
+}
+
+func command(dir, name string, args ...string) *exec.Cmd {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod")
+	return cmd
+}
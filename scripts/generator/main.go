@@ -1,9 +1,9 @@
 package main
 
 import (
-	"fmt"
 	"os"
-	"os/exec"
+
+	"github.com/liam/go_web_quick_start/scripts/generator/pkg/tableutil"
 )
 
 // 从project_generator.go导入CreateProject函数
@@ -17,17 +17,10 @@ import (
 func main() {
 	args := os.Args
 	if len(args) > 1 && args[1] == "create-table" {
-
-		cmd := exec.Command("go", "run", "./cmd/table_generator/main.go")
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-		cmd.Stdin = os.Stdin
-		if err := cmd.Run(); err != nil {
-			fmt.Printf("运行表代码生成器失败: %v\n", err)
-			os.Exit(1)
-		}
+		// 模板已嵌入二进制文件，直接调用表生成器，无需依赖当前工作目录
+		tableutil.Run(args[2:])
 	} else {
-		// 调用项目生成函数
-		CreateProject()
+		// 调用项目生成函数，参数如 --name、--config、--yes 透传
+		CreateProject(args[1:])
 	}
 }
@@ -13,19 +13,27 @@ type ModelConfig struct {
 
 // ProjectConfig 存储用户输入的项目配置信息
 type ProjectConfig struct {
-	ProjectName   string
-	ProjectPath   string
-	DBType        string
-	DBHost        string
-	DBPort        string
-	DBUser        string
-	DBPassword    string
-	DBName        string
-	RedisHost     string
-	RedisPort     string
-	RedisPassword string
-	RedisDB       string
-	ServerPort    string
+	ProjectName   string `yaml:"project_name" json:"project_name"`
+	ProjectPath   string `yaml:"project_path" json:"project_path"`
+	DBType        string `yaml:"db_type" json:"db_type"`
+	DBHost        string `yaml:"db_host" json:"db_host"`
+	DBPort        string `yaml:"db_port" json:"db_port"`
+	DBUser        string `yaml:"db_user" json:"db_user"`
+	DBPassword    string `yaml:"db_password" json:"db_password"`
+	DBName        string `yaml:"db_name" json:"db_name"`
+	RedisHost     string `yaml:"redis_host" json:"redis_host"`
+	RedisPort     string `yaml:"redis_port" json:"redis_port"`
+	RedisPassword string `yaml:"redis_password" json:"redis_password"`
+	RedisDB       string `yaml:"redis_db" json:"redis_db"`
+	ServerPort    string `yaml:"server_port" json:"server_port"`
+	ResponseStyle string `yaml:"response_style" json:"response_style"` // envelope 或 bare
+	GRPCPort      string `yaml:"grpc_port" json:"grpc_port"`           // gRPC端口，为空时不生成gRPC服务
+	GraphQL       string `yaml:"graphql" json:"graphql"`               // true 时生成GraphQL接口
+}
+
+// GraphQLEnabled 是否生成GraphQL接口
+func (c ProjectConfig) GraphQLEnabled() bool {
+	return c.GraphQL == "true"
 }
 
 // TableConfig 表配置
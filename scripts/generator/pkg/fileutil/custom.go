@@ -0,0 +1,117 @@
+package fileutil
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// 自定义代码区域标记，模板中成对出现，例如：
+//
+//	// @custom:begin methods
+//	...手写代码...
+//	// @custom:end methods
+//
+// 重新生成文件时，已有文件中同名区域内的代码会被保留
+const (
+	customBegin = "// @custom:begin "
+	customEnd   = "// @custom:end "
+)
+
+// ErrNoCustomRegions 新生成的内容中有自定义代码区域，已有文件中却没有任何标记
+// 通常是标记被删除或文件由不支持自定义区域的旧版本生成，无法区分哪些是手写代码
+var ErrNoCustomRegions = errors.New("已有文件中没有自定义代码区域标记")
+
+// 自定义代码区域
+type customRegion struct {
+	name  string
+	begin int // 开始标记所在行
+	end   int // 结束标记所在行
+}
+
+// 查找内容中的所有自定义代码区域
+func findCustomRegions(lines []string) ([]customRegion, error) {
+	var regions []customRegion
+	var current *customRegion
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, customBegin):
+			if current != nil {
+				return nil, fmt.Errorf("第 %d 行: 自定义代码区域 %s 未结束", i+1, current.name)
+			}
+			current = &customRegion{name: regionName(trimmed, customBegin), begin: i}
+		case strings.HasPrefix(trimmed, customEnd):
+			name := regionName(trimmed, customEnd)
+			if current == nil || current.name != name {
+				return nil, fmt.Errorf("第 %d 行: 自定义代码区域 %s 的结束标记没有对应的开始标记", i+1, name)
+			}
+			current.end = i
+			regions = append(regions, *current)
+			current = nil
+		}
+	}
+	if current != nil {
+		return nil, fmt.Errorf("自定义代码区域 %s 未结束", current.name)
+	}
+	return regions, nil
+}
+
+// 区域名为标记后的第一个单词，其后可以跟说明文字
+func regionName(line, marker string) string {
+	fields := strings.Fields(strings.TrimPrefix(line, marker))
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+// MergeCustomRegions 将已有文件中自定义代码区域内的代码合并到新生成的内容中
+// 已有文件中的区域在新内容中不存在时返回错误，避免手写代码丢失；
+// 新内容中有区域而已有文件中没有任何标记时返回 ErrNoCustomRegions
+func MergeCustomRegions(existing, generated []byte) ([]byte, error) {
+	oldLines := strings.Split(string(existing), "\n")
+	oldRegions, err := findCustomRegions(oldLines)
+	if err != nil {
+		return nil, fmt.Errorf("解析已有文件失败: %v", err)
+	}
+
+	newLines := strings.Split(string(generated), "\n")
+	newRegions, err := findCustomRegions(newLines)
+	if err != nil {
+		return nil, fmt.Errorf("解析生成内容失败: %v", err)
+	}
+	if len(oldRegions) == 0 {
+		if len(newRegions) > 0 {
+			return nil, ErrNoCustomRegions
+		}
+		return generated, nil
+	}
+
+	kept := make(map[string][]string)
+	for _, region := range oldRegions {
+		kept[region.name] = oldLines[region.begin+1 : region.end]
+	}
+
+	var merged []string
+	last := 0
+	for _, region := range newRegions {
+		body, ok := kept[region.name]
+		if !ok {
+			continue
+		}
+		merged = append(merged, newLines[last:region.begin+1]...)
+		merged = append(merged, body...)
+		last = region.end
+		delete(kept, region.name)
+	}
+	merged = append(merged, newLines[last:]...)
+
+	for name, body := range kept {
+		if strings.TrimSpace(strings.Join(body, "")) != "" {
+			return nil, fmt.Errorf("新生成的内容中没有自定义代码区域 %s，为避免丢失手写代码已停止生成", name)
+		}
+	}
+	return []byte(strings.Join(merged, "\n")), nil
+}
@@ -0,0 +1,187 @@
+package fileutil
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// 重新生成时的模板输出，methods 区域为空
+const generatedWithRegion = `package service
+
+func (s *postService) Get() {}
+
+// @custom:begin methods 自定义方法，重新生成时保留
+// @custom:end methods
+`
+
+// 已有文件，methods 区域中有手写代码
+const existingWithRegion = `package service
+
+func (s *postService) Get() {}
+
+// @custom:begin methods 自定义方法，重新生成时保留
+func (s *postService) Publish() {}
+// @custom:end methods
+`
+
+func TestMergeCustomRegions(t *testing.T) {
+	tests := []struct {
+		name      string
+		existing  string
+		generated string
+		want      string
+		wantErr   string // 为空时应成功
+	}{
+		{
+			name:      "保留区域内的代码",
+			existing:  existingWithRegion,
+			generated: strings.Replace(generatedWithRegion, "Get() {}", "Get() {}\n\nfunc (s *postService) List() {}", 1),
+			want:      strings.Replace(existingWithRegion, "Get() {}", "Get() {}\n\nfunc (s *postService) List() {}", 1),
+		},
+		{
+			name:      "区域外的修改被覆盖",
+			existing:  strings.Replace(existingWithRegion, "Get() {}", "Get() { panic(1) }", 1),
+			generated: generatedWithRegion,
+			want:      existingWithRegion,
+		},
+		{
+			name:      "只按区域名匹配，说明文字可以不同",
+			existing:  existingWithRegion,
+			generated: strings.Replace(generatedWithRegion, "methods 自定义方法，重新生成时保留", "methods", 1),
+			want:      strings.Replace(existingWithRegion, "methods 自定义方法，重新生成时保留", "methods", 1),
+		},
+		{
+			name:      "非空区域被重命名",
+			existing:  existingWithRegion,
+			generated: strings.ReplaceAll(generatedWithRegion, "methods", "extra"),
+			wantErr:   "没有自定义代码区域 methods",
+		},
+		{
+			name:      "非空区域被删除",
+			existing:  existingWithRegion,
+			generated: "package service\n\n// @custom:begin imports\n// @custom:end imports\n",
+			wantErr:   "没有自定义代码区域 methods",
+		},
+		{
+			name:      "删除空区域",
+			existing:  generatedWithRegion,
+			generated: "package service\n\n// @custom:begin imports\n// @custom:end imports\n",
+			want:      "package service\n\n// @custom:begin imports\n// @custom:end imports\n",
+		},
+		{
+			name:      "已有文件中没有标记",
+			existing:  "package service\n\nfunc (s *postService) Publish() {}\n",
+			generated: generatedWithRegion,
+			wantErr:   ErrNoCustomRegions.Error(),
+		},
+		{
+			name:      "两边都没有标记",
+			existing:  "package model\n\ntype Post struct{}\n",
+			generated: "package model\n\ntype Post struct{ Title string }\n",
+			want:      "package model\n\ntype Post struct{ Title string }\n",
+		},
+		{
+			name:      "已有文件缺少结束标记",
+			existing:  strings.Replace(existingWithRegion, "// @custom:end methods\n", "", 1),
+			generated: generatedWithRegion,
+			wantErr:   "解析已有文件失败: 自定义代码区域 methods 未结束",
+		},
+		{
+			name:      "已有文件缺少开始标记",
+			existing:  strings.Replace(existingWithRegion, "// @custom:begin methods 自定义方法，重新生成时保留\n", "", 1),
+			generated: generatedWithRegion,
+			wantErr:   "第 6 行: 自定义代码区域 methods 的结束标记没有对应的开始标记",
+		},
+		{
+			name:      "区域嵌套",
+			existing:  strings.Replace(existingWithRegion, "func (s *postService) Publish() {}", "// @custom:begin inner\n// @custom:end inner", 1),
+			generated: generatedWithRegion,
+			wantErr:   "第 6 行: 自定义代码区域 methods 未结束",
+		},
+		{
+			name:      "结束标记与开始标记不同名",
+			existing:  strings.Replace(existingWithRegion, "// @custom:end methods", "// @custom:end other", 1),
+			generated: generatedWithRegion,
+			wantErr:   "自定义代码区域 other 的结束标记没有对应的开始标记",
+		},
+		{
+			name:      "生成内容中的标记不成对",
+			existing:  existingWithRegion,
+			generated: strings.Replace(generatedWithRegion, "// @custom:end methods\n", "", 1),
+			wantErr:   "解析生成内容失败",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, err := MergeCustomRegions([]byte(tt.existing), []byte(tt.generated))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("MergeCustomRegions() error = %v, 期望包含 %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("MergeCustomRegions() error = %v", err)
+			}
+			if string(merged) != tt.want {
+				t.Errorf("MergeCustomRegions() =\n%s\n期望\n%s", merged, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteFileBacksUpFileWithoutRegions(t *testing.T) {
+	var out bytes.Buffer
+	useMode(t, false, false, &out)
+
+	path := filepath.Join(t.TempDir(), "post_service.go")
+	handwritten := "package service\n\nfunc (s *postService) Publish() {}\n"
+	if err := os.WriteFile(path, []byte(handwritten), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := WriteFile(path, []byte(generatedWithRegion)); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if got := readFile(t, path+".bak"); got != handwritten {
+		t.Errorf("备份文件内容 = %q, 期望 %q", got, handwritten)
+	}
+	if got := readFile(t, path); got != generatedWithRegion {
+		t.Errorf("文件内容 = %q, 期望为新生成的内容", got)
+	}
+	if !strings.Contains(out.String(), path+".bak") {
+		t.Errorf("没有提示备份文件: %q", out.String())
+	}
+
+	// 已有标记后再次生成不会再备份
+	if err := os.Remove(path + ".bak"); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFile(path, []byte(generatedWithRegion)); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if _, err := os.Stat(path + ".bak"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("已有标记时不应备份, Stat() error = %v", err)
+	}
+}
+
+func TestWriteFileKeepsFileWithUnbalancedRegions(t *testing.T) {
+	useMode(t, false, false, &bytes.Buffer{})
+
+	path := filepath.Join(t.TempDir(), "post_service.go")
+	broken := strings.Replace(existingWithRegion, "// @custom:end methods\n", "", 1)
+	if err := os.WriteFile(path, []byte(broken), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteFile(path, []byte(generatedWithRegion)); err == nil || !strings.Contains(err.Error(), path) {
+		t.Fatalf("WriteFile() error = %v, 期望包含文件名的错误", err)
+	}
+	if got := readFile(t, path); got != broken {
+		t.Errorf("标记不成对时不应修改文件, 内容为 %q", got)
+	}
+}
@@ -0,0 +1,165 @@
+// Package fileutil 生成器统一的文件写入，支持预览(dry-run)和差异(diff)模式
+package fileutil
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+var (
+	dryRun bool
+	diff   bool
+
+	// Output 预览和差异信息的输出位置
+	Output io.Writer = os.Stdout
+)
+
+// SetMode 设置写入模式，dry-run 和 diff 模式下都不会写入任何文件
+func SetMode(dryRunMode, diffMode bool) {
+	dryRun = dryRunMode
+	diff = diffMode
+}
+
+// Preview 是否处于只预览不写入的模式
+func Preview() bool {
+	return dryRun || diff
+}
+
+// MkdirAll 创建目录，预览模式下不创建
+func MkdirAll(path string) error {
+	if Preview() {
+		return nil
+	}
+	return os.MkdirAll(path, 0755)
+}
+
+// ReadFile 读取已存在的文件，文件不存在时返回 nil
+func ReadFile(path string) ([]byte, bool, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return content, true, nil
+}
+
+// WriteFile 写入文件
+// Go源文件写入前经过gofmt和goimports处理，语法错误时不写入
+// 已有文件中自定义代码区域内的代码会被保留，见 MergeCustomRegions；
+// 已有文件中没有区域标记时先备份为 xxx.bak 再覆盖
+// dry-run 模式下只列出将创建或修改的文件，diff 模式下输出与磁盘内容的统一差异
+func WriteFile(path string, content []byte) error {
+	var err error
+	if isGoFile(path) {
+		if content, err = FormatGo(path, content); err != nil {
+			return err
+		}
+	}
+
+	old, exists, err := ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("读取文件 %s 失败: %v", path, err)
+	}
+	if exists {
+		merged, err := MergeCustomRegions(old, content)
+		switch {
+		case errors.Is(err, ErrNoCustomRegions):
+			// 无法区分手写代码，备份已有文件后覆盖
+			if err := backupFile(path, old); err != nil {
+				return err
+			}
+		case err != nil:
+			return fmt.Errorf("合并文件 %s 的自定义代码失败: %v", path, err)
+		default:
+			// 保留的手写代码同样需要格式化
+			content = merged
+			if isGoFile(path) {
+				if content, err = FormatGo(path, content); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if !Preview() {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("创建目录 %s 失败: %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			return fmt.Errorf("写入文件 %s 失败: %v", path, err)
+		}
+		return nil
+	}
+
+	action := "创建"
+	if exists {
+		if bytes.Equal(old, content) {
+			if dryRun {
+				fmt.Fprintf(Output, "未变化 %s\n", path)
+			}
+			return nil
+		}
+		action = "修改"
+	}
+
+	if dryRun {
+		fmt.Fprintf(Output, "%s %s\n", action, path)
+	}
+	if diff {
+		fromFile := path
+		if !exists {
+			fromFile = "/dev/null"
+		}
+		text, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        splitLines(old),
+			B:        splitLines(content),
+			FromFile: fromFile,
+			ToFile:   path,
+			Context:  3,
+		})
+		if err != nil {
+			return fmt.Errorf("生成文件 %s 的差异失败: %v", path, err)
+		}
+		fmt.Fprint(Output, text)
+	}
+	return nil
+}
+
+// 按行拆分并保留换行符，与 difflib.SplitLines 不同，不会在末尾多出一个空行
+func splitLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+	lines := strings.SplitAfter(string(content), "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+	lines[len(lines)-1] += "\n"
+	return lines
+}
+
+// 将已有文件备份为 path.bak，dry-run 模式下只列出，diff 模式下不输出
+func backupFile(path string, content []byte) error {
+	backup := path + ".bak"
+	if Preview() {
+		if dryRun {
+			fmt.Fprintf(Output, "备份 %s\n", backup)
+		}
+		return nil
+	}
+	if err := os.WriteFile(backup, content, 0644); err != nil {
+		return fmt.Errorf("备份文件 %s 失败: %v", path, err)
+	}
+	fmt.Fprintf(Output, "提示: %s 中没有自定义代码区域标记，已备份到 %s 后重新生成\n", path, backup)
+	return nil
+}
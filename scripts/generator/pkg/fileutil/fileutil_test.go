@@ -0,0 +1,122 @@
+package fileutil
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// 设置写入模式和输出位置，测试结束后恢复默认值
+func useMode(t *testing.T, dryRunMode, diffMode bool, out io.Writer) {
+	t.Helper()
+	SetMode(dryRunMode, diffMode)
+	Output = out
+	t.Cleanup(func() {
+		SetMode(false, false)
+		Output = os.Stdout
+	})
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+// 临时目录中的已有文件 config.yaml 和 unchanged.yaml，以及不存在的 new/new.yaml
+func previewFixture(t *testing.T) (changed, unchanged, created string) {
+	t.Helper()
+	dir := t.TempDir()
+	changed = filepath.Join(dir, "config.yaml")
+	unchanged = filepath.Join(dir, "unchanged.yaml")
+	created = filepath.Join(dir, "new", "new.yaml")
+	if err := os.WriteFile(changed, []byte("a: 1\nb: 2\nc: 3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(unchanged, []byte("a: 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return changed, unchanged, created
+}
+
+func TestWriteFileDryRun(t *testing.T) {
+	var out bytes.Buffer
+	useMode(t, true, false, &out)
+	changed, unchanged, created := previewFixture(t)
+
+	for path, content := range map[string]string{changed: "a: 1\nb: 20\nc: 3\n", unchanged: "a: 1\n", created: "x: 1\n"} {
+		if err := WriteFile(path, []byte(content)); err != nil {
+			t.Fatalf("WriteFile(%s) error = %v", path, err)
+		}
+	}
+	if err := MkdirAll(filepath.Dir(created)); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := readFile(t, changed); got != "a: 1\nb: 2\nc: 3\n" {
+		t.Errorf("dry-run 模式下修改了文件: %q", got)
+	}
+	if _, err := os.Stat(filepath.Dir(created)); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("dry-run 模式下创建了目录, Stat() error = %v", err)
+	}
+	for _, want := range []string{"修改 " + changed + "\n", "未变化 " + unchanged + "\n", "创建 " + created + "\n"} {
+		if !bytes.Contains(out.Bytes(), []byte(want)) {
+			t.Errorf("输出中缺少 %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestWriteFileDiff(t *testing.T) {
+	var out bytes.Buffer
+	useMode(t, false, true, &out)
+	changed, unchanged, created := previewFixture(t)
+
+	if err := WriteFile(unchanged, []byte("a: 1\n")); err != nil {
+		t.Fatal(err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("未变化的文件不应输出差异:\n%s", out.String())
+	}
+
+	if err := WriteFile(changed, []byte("a: 1\nb: 20\nc: 3\n")); err != nil {
+		t.Fatal(err)
+	}
+	want := "--- " + changed + "\n+++ " + changed + "\n@@ -1,3 +1,3 @@\n a: 1\n-b: 2\n+b: 20\n c: 3\n"
+	if out.String() != want {
+		t.Errorf("修改文件的差异 =\n%s\n期望\n%s", out.String(), want)
+	}
+	if got := readFile(t, changed); got != "a: 1\nb: 2\nc: 3\n" {
+		t.Errorf("diff 模式下修改了文件: %q", got)
+	}
+
+	out.Reset()
+	if err := WriteFile(created, []byte("x: 1\n")); err != nil {
+		t.Fatal(err)
+	}
+	want = "--- /dev/null\n+++ " + created + "\n@@ -0,0 +1 @@\n+x: 1\n"
+	if out.String() != want {
+		t.Errorf("新文件的差异 =\n%s\n期望\n%s", out.String(), want)
+	}
+	if _, err := os.Stat(created); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("diff 模式下创建了文件, Stat() error = %v", err)
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	useMode(t, false, false, &bytes.Buffer{})
+	path := filepath.Join(t.TempDir(), "internal", "model", "post.go")
+
+	if err := WriteFile(path, []byte("package model\nimport \"fmt\"\ntype Post struct{Title string}\n")); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	want := "package model\n\ntype Post struct{ Title string }\n"
+	if got := readFile(t, path); got != want {
+		t.Errorf("写入的内容 = %q, 期望经过格式化并删除未使用的导入 %q", got, want)
+	}
+}
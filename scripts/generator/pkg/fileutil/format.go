@@ -0,0 +1,27 @@
+package fileutil
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"golang.org/x/tools/imports"
+)
+
+// FormatGo 按gofmt格式化Go源码，并排序、补全和清理导入
+// 源码存在语法错误时返回带文件名和行号的错误
+func FormatGo(path string, src []byte) ([]byte, error) {
+	formatted, err := imports.Process(path, src, &imports.Options{
+		Comments:  true,
+		TabIndent: true,
+		TabWidth:  8,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("生成的Go代码无效: %v", err)
+	}
+	return formatted, nil
+}
+
+// 是否为Go源文件
+func isGoFile(path string) bool {
+	return filepath.Ext(path) == ".go"
+}
@@ -0,0 +1,57 @@
+package fileutil
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFormatGo(t *testing.T) {
+	src := "package dao\nimport (\"strings\"\n\"fmt\")\nfunc Name() string { return fmt.Sprint(1) }\n"
+	formatted, err := FormatGo("internal/dao/post.go", []byte(src))
+	if err != nil {
+		t.Fatalf("FormatGo() error = %v", err)
+	}
+	want := "package dao\n\nimport (\n\t\"fmt\"\n)\n\nfunc Name() string { return fmt.Sprint(1) }\n"
+	if string(formatted) != want {
+		t.Errorf("FormatGo() =\n%s\n期望\n%s", formatted, want)
+	}
+}
+
+func TestFormatGoInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string // 错误中应包含的文件名和行号
+	}{
+		{name: "缺少右括号", src: "package dao\n\nfunc Get() {\n\treturn\n", want: "internal/dao/post.go:4:9"},
+		{name: "模板输出了多余的内容", src: "package dao\n\nfunc Get() {}\n}\n", want: "internal/dao/post.go:4:1"},
+		{name: "缺少package", src: "func Get() {}\n", want: "internal/dao/post.go:1:1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FormatGo("internal/dao/post.go", []byte(tt.src))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("FormatGo() error = %v, 期望包含 %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteFileInvalidGo(t *testing.T) {
+	useMode(t, false, false, &bytes.Buffer{})
+	path := filepath.Join(t.TempDir(), "post.go")
+	if err := os.WriteFile(path, []byte("package dao\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := WriteFile(path, []byte("package dao\n\nfunc Get() {\n"))
+	if err == nil || !strings.Contains(err.Error(), path+":") {
+		t.Fatalf("WriteFile() error = %v, 期望包含文件名 %s", err, path)
+	}
+	if got := readFile(t, path); got != "package dao\n" {
+		t.Errorf("语法错误时不应写入文件, 内容为 %q", got)
+	}
+}
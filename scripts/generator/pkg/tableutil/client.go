@@ -0,0 +1,86 @@
+package tableutil
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/liam/go_web_quick_start/scripts/generator/pkg/fileutil"
+)
+
+// GenerateClients 生成模型的Go客户端 client/xxx.go 和TypeScript客户端 client/typescript/xxx.ts
+// 客户端的公共代码 client/client.go、client/typescript/client.ts 不存在时一并生成，已存在时不覆盖
+func GenerateClients(projectRoot, templatesDir string, config ModelConfig, responseStyle string) error {
+	clientDir := filepath.Join(projectRoot, "client")
+	tsDir := filepath.Join(clientDir, "typescript")
+	data := openAPIData{ModelConfig: config, ResponseStyle: responseStyle}
+
+	baseFiles := []struct {
+		path     string
+		template string
+	}{
+		{filepath.Join(clientDir, "client.go"), "client_base.tmpl"},
+		{filepath.Join(tsDir, "client.ts"), "client_base_ts.tmpl"},
+	}
+	for _, file := range baseFiles {
+		_, exists, err := fileutil.ReadFile(file.path)
+		if err != nil {
+			return fmt.Errorf("读取文件 %s 失败: %v", file.path, err)
+		}
+		if exists {
+			continue
+		}
+		if err := GenerateFileFromTemplate(file.path, templatesDir, file.template, data); err != nil {
+			return err
+		}
+	}
+
+	fileName := strings.ToLower(config.ModuleName)
+	if err := GenerateFileFromTemplate(filepath.Join(clientDir, fileName+".go"), templatesDir, "client.tmpl", config); err != nil {
+		return err
+	}
+	return GenerateFileFromTemplate(filepath.Join(tsDir, fileName+".ts"), templatesDir, "client_ts.tmpl", config)
+}
+
+// Go类型对应的TypeScript类型
+func tsType(goType string) string {
+	switch {
+	case goType == "string", goType == "time.Time", goType == "[]byte":
+		return "string"
+	case goType == "bool":
+		return "boolean"
+	case strings.HasPrefix(goType, "int"), strings.HasPrefix(goType, "uint"), strings.HasPrefix(goType, "float"):
+		return "number"
+	}
+	return "unknown"
+}
+
+// TSType 字段的TypeScript类型，可空字段包含 null
+func (f Field) TSType() string {
+	if strings.HasPrefix(f.Type, "*") {
+		return tsType(f.BaseType()) + " | null"
+	}
+	return tsType(f.Type)
+}
+
+// IDTSType 主键的TypeScript类型
+func (c ModelConfig) IDTSType() string {
+	if c.ID == "" {
+		return tsType("uint")
+	}
+	return tsType(c.ID)
+}
+
+// TSImports TypeScript客户端中关联模型的导入语句，文件名与生成的客户端文件一致
+func (c ModelConfig) TSImports() []string {
+	var imports []string
+	seen := map[string]bool{c.ModelName: true}
+	for _, relation := range c.Relations {
+		if seen[relation.Model] {
+			continue
+		}
+		seen[relation.Model] = true
+		imports = append(imports, fmt.Sprintf("import type { %s } from './%s';", relation.Model, strings.ToLower(relation.Model)))
+	}
+	return imports
+}
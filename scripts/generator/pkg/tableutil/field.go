@@ -0,0 +1,330 @@
+package tableutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 字段定义语法，交互输入和模型定义文件中通用：
+//
+//	字段名 类型 [属性...] [`自定义标签`]
+//
+// 属性：
+//
+//	required           必填，生成 not null 和 binding:"required"
+//	not_null           列不可为空，但不做必填校验（如有默认值的数字列）
+//	nullable           可空，Go类型使用指针
+//	unique             唯一索引
+//	index              普通索引
+//	size=100           最大长度，字符串生成 varchar(100) 和 binding:"max=100"
+//	min=1 max=10       取值范围（字符串为长度范围）
+//	default=1          默认值
+//	column=user_name   列名，默认为字段名的下划线形式
+//	column_type=text   数据库列类型
+//	comment=用户名      注释
+//
+// 属性值包含空格时可以用单引号或双引号括起来，例如 comment='用户 名称'。
+// 无法识别的属性视为错误，避免拼写错误的属性被静默忽略。
+// 例如：name string required unique size=100 comment=用户名
+const FieldSyntax = "字段名 类型 [required] [not_null] [nullable] [unique] [index] [size=N] [min=N] [max=N] [default=V] [column=列名] [column_type=列类型] [comment=注释]"
+
+// 字段定义中可用的属性，用于错误提示
+const fieldAttributes = "required、not_null、nullable、unique、index、size=N、min=N、max=N、default=V、column=列名、column_type=列类型、comment=注释"
+
+// ParseField 按字段定义语法解析一行字段定义
+func ParseField(line string) (Field, error) {
+	tokens, err := splitFieldTokens(line)
+	if err != nil {
+		return Field{}, err
+	}
+	if len(tokens) < 2 {
+		return Field{}, fmt.Errorf("字段定义至少需要字段名和类型: %s", line)
+	}
+
+	field := Field{Name: tokens[0], Type: tokens[1]}
+	var comment []string
+	for _, token := range tokens[2:] {
+		// 兼容旧格式：用反引号括起来的完整标签
+		if strings.HasPrefix(token, "`") {
+			field.Tag = token
+			continue
+		}
+
+		key, value, hasValue := strings.Cut(token, "=")
+		switch {
+		case !hasValue && key == "required":
+			field.Required = true
+		case !hasValue && key == "not_null":
+			field.NotNull = true
+		case !hasValue && key == "nullable":
+			field.Nullable = true
+		case !hasValue && key == "unique":
+			field.Unique = true
+		case !hasValue && key == "index":
+			field.Index = true
+		case hasValue && key == "size":
+			size, err := strconv.Atoi(value)
+			if err != nil || size <= 0 {
+				return Field{}, fmt.Errorf("字段 %s 的 size 必须是正整数: %s", field.Name, value)
+			}
+			field.Size = size
+		case hasValue && key == "min":
+			field.Min = value
+		case hasValue && key == "max":
+			field.Max = value
+		case hasValue && key == "default":
+			field.Default = value
+		case hasValue && key == "column":
+			field.Column = value
+		case hasValue && key == "column_type":
+			field.ColumnType = value
+		case hasValue && key == "comment":
+			comment = append(comment, value)
+		default:
+			return Field{}, fmt.Errorf("字段 %s 的属性 %s 无效，可用的属性: %s", field.Name, token, fieldAttributes)
+		}
+	}
+	field.Comment = strings.Join(comment, " ")
+	return field, nil
+}
+
+// 按空白拆分字段定义，支持引号和反引号括起来的值
+func splitFieldTokens(line string) ([]string, error) {
+	var tokens []string
+	var current strings.Builder
+	var quote rune
+	inToken := false
+
+	for _, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				if quote == '`' {
+					current.WriteRune(r)
+				}
+				quote = 0
+				continue
+			}
+			current.WriteRune(r)
+		case r == '"' || r == '\'' || r == '`':
+			quote = r
+			inToken = true
+			if r == '`' {
+				current.WriteRune(r)
+			}
+		case unicode.IsSpace(r):
+			if inToken {
+				tokens = append(tokens, current.String())
+				current.Reset()
+				inToken = false
+			}
+		default:
+			current.WriteRune(r)
+			inToken = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("字段定义中的引号未闭合: %s", line)
+	}
+	if inToken {
+		tokens = append(tokens, current.String())
+	}
+	return tokens, nil
+}
+
+// UnmarshalYAML 模型定义文件中的字段既可以是映射，也可以是一行字段定义
+func (f *Field) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		field, err := ParseField(value.Value)
+		if err != nil {
+			return err
+		}
+		*f = field
+		return nil
+	}
+
+	type plain Field
+	return value.Decode((*plain)(f))
+}
+
+// UnmarshalJSON 模型定义文件中的字段既可以是对象，也可以是一行字段定义
+func (f *Field) UnmarshalJSON(data []byte) error {
+	var line string
+	if err := json.Unmarshal(data, &line); err == nil {
+		field, err := ParseField(line)
+		if err != nil {
+			return err
+		}
+		*f = field
+		return nil
+	}
+
+	type plain Field
+	return json.Unmarshal(data, (*plain)(f))
+}
+
+// 补全字段的默认值：导出的Go字段名、列名，可空字段使用指针类型
+func normalizeField(field *Field) error {
+	if field.Name == "" || field.Type == "" {
+		return fmt.Errorf("缺少字段名或类型")
+	}
+	if (field.Required || field.NotNull) && field.Nullable {
+		return fmt.Errorf("字段 %s 不能同时为 nullable 和 required/not_null", field.Name)
+	}
+	if field.Unique && field.Index {
+		return fmt.Errorf("字段 %s 不能同时为 unique 和 index", field.Name)
+	}
+
+	if field.Column == "" {
+		field.Column = toSnakeCase(field.Name)
+	}
+	field.Name = exportedName(field.Name)
+	if field.Nullable && !strings.HasPrefix(field.Type, "*") && !strings.HasPrefix(field.Type, "[]") {
+		field.Type = "*" + field.Type
+	}
+
+	// 模型模板直接输出标签，文件中可以省略反引号
+	if field.Tag != "" && !strings.HasPrefix(field.Tag, "`") {
+		field.Tag = "`" + field.Tag + "`"
+	}
+	return nil
+}
+
+// JSONName JSON字段名，与列名一致
+func (f Field) JSONName() string {
+	return f.Column
+}
+
+// BaseType 去掉指针后的Go类型
+func (f Field) BaseType() string {
+	return strings.TrimPrefix(f.Type, "*")
+}
+
+// IsString 是否为字符串字段
+func (f Field) IsString() bool {
+	return f.BaseType() == "string"
+}
+
+// GormTag 根据字段属性生成gorm标签
+func (f Field) GormTag() string {
+	parts := []string{"column:" + f.Column}
+	switch {
+	case f.ColumnType != "":
+		parts = append(parts, "type:"+f.ColumnType)
+	case f.Size > 0 && f.IsString():
+		parts = append(parts, fmt.Sprintf("type:varchar(%d)", f.Size))
+	case f.Size > 0:
+		parts = append(parts, fmt.Sprintf("size:%d", f.Size))
+	}
+	if f.Required || f.NotNull {
+		parts = append(parts, "not null")
+	}
+	if f.Unique {
+		parts = append(parts, "uniqueIndex")
+	} else if f.Index {
+		parts = append(parts, "index")
+	}
+	if f.Default != "" {
+		parts = append(parts, "default:"+tagValue(f.Default))
+	}
+	if f.Comment != "" {
+		parts = append(parts, "comment:"+tagValue(f.Comment))
+	}
+	return strings.Join(parts, ";")
+}
+
+// BindingTag 根据字段属性生成gin的binding校验标签
+func (f Field) BindingTag() string {
+	var limits []string
+	if f.Min != "" {
+		limits = append(limits, "min="+f.Min)
+	}
+	switch {
+	case f.Max != "":
+		limits = append(limits, "max="+f.Max)
+	case f.Size > 0 && f.IsString():
+		limits = append(limits, fmt.Sprintf("max=%d", f.Size))
+	}
+
+	switch {
+	case f.Required:
+		return strings.Join(append([]string{"required"}, limits...), ",")
+	case len(limits) > 0:
+		// 非必填字段为空时不校验取值范围
+		return strings.Join(append([]string{"omitempty"}, limits...), ",")
+	}
+	return ""
+}
+
+// QueryType 字段在生成项目 dao.Columns 中的值类型，不支持查询的类型返回空
+func (f Field) QueryType() string {
+	return queryType(f.BaseType())
+}
+
+// Go类型对应的 dao.ColumnType 常量名
+func queryType(goType string) string {
+	switch {
+	case goType == "[]byte":
+		return ""
+	case strings.HasPrefix(goType, "uint"):
+		return "ColumnUint"
+	case strings.HasPrefix(goType, "int"):
+		return "ColumnInt"
+	case strings.HasPrefix(goType, "float"):
+		return "ColumnFloat"
+	case goType == "bool":
+		return "ColumnBool"
+	case goType == "time.Time":
+		return "ColumnTime"
+	}
+	return "ColumnString"
+}
+
+// StructTag 字段的完整结构体标签，填写了自定义标签时直接使用
+func (f Field) StructTag() string {
+	if f.Tag != "" {
+		return f.Tag
+	}
+
+	tag := fmt.Sprintf(`gorm:"%s" json:"%s"`, f.GormTag(), f.JSONName())
+	if binding := f.BindingTag(); binding != "" {
+		tag += fmt.Sprintf(` binding:"%s"`, binding)
+	}
+	return "`" + tag + "`"
+}
+
+// 字段名转换为导出的Go字段名，下划线命名转为驼峰，驼峰命名只大写首字母
+func exportedName(name string) string {
+	if strings.Contains(name, "_") || strings.ToLower(name) == name {
+		return toCamelCase(name)
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// 驼峰命名转换为下划线命名，如 UserID -> user_id
+func toSnakeCase(name string) string {
+	if strings.Contains(name, "_") || strings.ToLower(name) == name {
+		return strings.ToLower(name)
+	}
+
+	runes := []rune(name)
+	var builder strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			// 单词边界：前一个是小写，或者是连续大写的末尾（如 HTTPServer 中的 S）
+			if i > 0 && (unicode.IsLower(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsUpper(runes[i-1]) && unicode.IsLower(runes[i+1]))) {
+				builder.WriteByte('_')
+			}
+			builder.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
@@ -0,0 +1,87 @@
+package tableutil
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseField(t *testing.T) {
+	tests := []struct {
+		line string
+		want Field
+	}{
+		{
+			line: "name string",
+			want: Field{Name: "name", Type: "string"},
+		},
+		{
+			line: "name string required unique size=100 comment=用户名",
+			want: Field{Name: "name", Type: "string", Required: true, Unique: true, Size: 100, Comment: "用户名"},
+		},
+		{
+			line: "age int not_null index min=0 max=150 default=18",
+			want: Field{Name: "age", Type: "int", NotNull: true, Index: true, Min: "0", Max: "150", Default: "18"},
+		},
+		{
+			line: "nickname string nullable column=nick_name column_type=text",
+			want: Field{Name: "nickname", Type: "string", Nullable: true, Column: "nick_name", ColumnType: "text"},
+		},
+		{
+			line: `title string comment='文章 标题' default="a b"`,
+			want: Field{Name: "title", Type: "string", Comment: "文章 标题", Default: "a b"},
+		},
+		{
+			line: "code string `gorm:\"size:10\" json:\"code\"`",
+			want: Field{Name: "code", Type: "string", Tag: "`gorm:\"size:10\" json:\"code\"`"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got, err := ParseField(tt.line)
+			if err != nil {
+				t.Fatalf("ParseField(%q) 返回错误: %v", tt.line, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseField(%q) = %+v, 期望 %+v", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFieldErrors(t *testing.T) {
+	tests := []struct {
+		line string
+		want string // 错误信息中应包含的内容
+	}{
+		{line: "name", want: "至少需要字段名和类型"},
+		{line: "", want: "至少需要字段名和类型"},
+		{line: "name string size=abc", want: "size 必须是正整数"},
+		{line: "name string size=0", want: "size 必须是正整数"},
+		{line: "name string requird", want: "属性 requird 无效"},
+		{line: "name string lenght=10", want: "属性 lenght=10 无效"},
+		{line: "name string 用户名", want: "属性 用户名 无效"},
+		{line: "name string required=true", want: "属性 required=true 无效"},
+		{line: "name string comment='未闭合", want: "引号未闭合"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			_, err := ParseField(tt.line)
+			if err == nil {
+				t.Fatalf("ParseField(%q) 应返回错误", tt.line)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseField(%q) 的错误 %q 中应包含 %q", tt.line, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFieldErrorListsAttributes(t *testing.T) {
+	_, err := ParseField("name string requird")
+	if err == nil || !strings.Contains(err.Error(), fieldAttributes) {
+		t.Errorf("无效属性的错误中应列出可用的属性，实际为: %v", err)
+	}
+}
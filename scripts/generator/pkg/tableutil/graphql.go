@@ -0,0 +1,373 @@
+package tableutil
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/liam/go_web_quick_start/scripts/generator/pkg/fileutil"
+	"golang.org/x/tools/go/ast/astutil"
+	"gopkg.in/yaml.v3"
+)
+
+// GraphQL根解析器的类型名和构造函数名，与gql_resolver_base.tmpl保持一致
+const (
+	rootResolverType = "Resolver"
+	rootResolverFunc = "NewResolver"
+)
+
+// ErrResolverRegistered 模块的GraphQL解析器已注册到根解析器
+var ErrResolverRegistered = errors.New("已注册到 " + rootResolverFunc)
+
+// LoadGraphQLEnabled 读取项目config.yaml中的 graphql.enabled，为 false 或未配置时项目未启用GraphQL
+func LoadGraphQLEnabled(path string) bool {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+
+	var config struct {
+		GraphQL struct {
+			Enabled bool `yaml:"enabled"`
+		} `yaml:"graphql"`
+	}
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return false
+	}
+	return config.GraphQL.Enabled
+}
+
+// GenerateGraphQL 生成模型的GraphQL schema片段 internal/gql/schema/xxx.graphql 和解析器 internal/gql/xxx.go，并注册到根解析器
+// 公共代码 internal/gql/gql.go、internal/gql/schema/schema.graphql 不存在时一并生成，已存在时不覆盖
+func GenerateGraphQL(projectRoot, templatesDir string, config ModelConfig) error {
+	gqlDir := filepath.Join(projectRoot, "internal", "gql")
+	schemaDir := filepath.Join(gqlDir, "schema")
+
+	baseFiles := []struct {
+		path     string
+		template string
+	}{
+		{filepath.Join(gqlDir, "gql.go"), "gql_base.tmpl"},
+		{filepath.Join(schemaDir, "schema.graphql"), "gql_schema_base.tmpl"},
+	}
+	for _, file := range baseFiles {
+		_, exists, err := fileutil.ReadFile(file.path)
+		if err != nil {
+			return fmt.Errorf("读取文件 %s 失败: %v", file.path, err)
+		}
+		if exists {
+			continue
+		}
+		if err := GenerateFileFromTemplate(file.path, templatesDir, file.template, config); err != nil {
+			return err
+		}
+	}
+
+	fileName := strings.ToLower(config.ModuleName)
+	if err := GenerateFileFromTemplate(filepath.Join(schemaDir, fileName+".graphql"), templatesDir, "gql_schema.tmpl", config); err != nil {
+		return err
+	}
+	if err := GenerateFileFromTemplate(filepath.Join(gqlDir, fileName+".go"), templatesDir, "gql_resolver.tmpl", config); err != nil {
+		return err
+	}
+
+	err := UpdateGraphQLResolver(filepath.Join(gqlDir, "resolver.go"), templatesDir, config)
+	if errors.Is(err, ErrResolverRegistered) {
+		fmt.Printf("提示: %v，跳过注册GraphQL解析器\n", err)
+		return nil
+	}
+	return err
+}
+
+// UpdateGraphQLResolver 将模型的解析器注册到根解析器
+// 文件不存在时先由gql_resolver_base.tmpl创建；在 Resolver 结构体中嵌入 *XxxResolver，
+// 并在 NewResolver 的 return 之前插入gql_register.tmpl生成的代码
+func UpdateGraphQLResolver(filePath, templatesDir string, config ModelConfig) error {
+	content, exists, err := fileutil.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("读取文件 %s 失败: %v", filePath, err)
+	}
+	if !exists {
+		if content, err = executeTemplate(templatesDir, "gql_resolver_base.tmpl", config); err != nil {
+			return err
+		}
+	}
+	snippet, err := executeTemplate(templatesDir, "gql_register.tmpl", config)
+	if err != nil {
+		return err
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filePath, content, parser.ParseComments)
+	if err != nil {
+		return fmt.Errorf("解析 %s 失败: %v", filePath, err)
+	}
+	fn := findFunc(file, rootResolverFunc)
+	if fn == nil || fn.Body == nil || len(fn.Body.List) == 0 {
+		return fmt.Errorf("%s 中未找到 %s 函数", filePath, rootResolverFunc)
+	}
+	resolverFunc := "New" + config.ModelName + "Resolver"
+	if callsFunc(fn.Body, resolverFunc) {
+		return fmt.Errorf("%s %w", resolverFunc, ErrResolverRegistered)
+	}
+	rootStruct := findStruct(file, rootResolverType)
+	if rootStruct == nil {
+		return fmt.Errorf("%s 中未找到 %s 结构体", filePath, rootResolverType)
+	}
+	returnStmt, ok := fn.Body.List[len(fn.Body.List)-1].(*ast.ReturnStmt)
+	if !ok {
+		return fmt.Errorf("%s 的 %s 函数应以 return 语句结尾", filePath, rootResolverFunc)
+	}
+
+	// 插入到 return 之前，前后与其他代码各空一行
+	returnOffset := fset.Position(returnStmt.Pos()).Offset
+	for returnOffset > 0 && content[returnOffset-1] != '\n' {
+		returnOffset--
+	}
+	registration := string(snippet) + "\n"
+	if returnOffset < 2 || content[returnOffset-2] != '\n' {
+		registration = "\n" + registration
+	}
+	updated := applyEdits(content, []textEdit{
+		{offset: fset.Position(rootStruct.Fields.Closing).Offset, text: "\t*" + config.ModelName + "Resolver\n"},
+		{offset: returnOffset, text: registration},
+	})
+
+	// 确保导入了dao包和service包
+	file, err = parser.ParseFile(fset, filePath, updated, parser.ParseComments)
+	if err != nil {
+		return fmt.Errorf("合并后的 %s 无效: %v", filePath, err)
+	}
+	astutil.AddImport(fset, file, config.ProjectImport+"/internal/dao")
+	astutil.AddImport(fset, file, config.ProjectImport+"/internal/service")
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return fmt.Errorf("格式化 %s 失败: %v", filePath, err)
+	}
+	return fileutil.WriteFile(filePath, buf.Bytes())
+}
+
+// GraphQLField GraphQL类型中的字段
+type GraphQLField struct {
+	Name     string // 字段名，列名的小驼峰形式，如 created_at -> createdAt
+	Type     string // GraphQL类型，不含 !
+	Optional bool   // 模型字段为指针，可以为 null
+	Required bool   // 创建和更新时必填
+	Input    bool   // 是否出现在 XxxInput 中
+	Column   string // 数据库列名，只有 XxxInput 中的字段有
+	GoName   string // 模型结构体中的字段名
+	GoType   string // 模型结构体中的字段类型
+}
+
+// Go类型对应的GraphQL类型，无法对应的类型返回空
+// 超出 Int（32位）范围的整数使用生成项目中定义的 Int64 标量
+func graphQLType(goType string) string {
+	switch goType {
+	case "string":
+		return "String"
+	case "bool":
+		return "Boolean"
+	case "int8", "int16", "int32", "uint8", "uint16":
+		return "Int"
+	case "int", "int64", "uint", "uint32", "uint64":
+		return "Int64"
+	case "float32", "float64":
+		return "Float"
+	case "time.Time":
+		return "Time"
+	}
+	return ""
+}
+
+// GraphQL类型在graphql-go中对应的Go类型
+func graphQLGoType(graphQLType string) string {
+	switch graphQLType {
+	case "String":
+		return "string"
+	case "Boolean":
+		return "bool"
+	case "Int":
+		return "int32"
+	case "Int64":
+		return "Int64"
+	case "Float":
+		return "float64"
+	case "Time":
+		return "graphql.Time"
+	}
+	return ""
+}
+
+// GraphQLFields 模型在GraphQL中的字段：id 之外的时间戳、模型字段，启用乐观锁时包含 version
+// 无法对应GraphQL类型的字段不生成
+func (c ModelConfig) GraphQLFields() []GraphQLField {
+	fields := []GraphQLField{
+		{Name: "createdAt", GoName: "CreatedAt", GoType: "time.Time"},
+		{Name: "updatedAt", GoName: "UpdatedAt", GoType: "time.Time"},
+	}
+	for _, field := range c.Fields {
+		fields = append(fields, GraphQLField{
+			Name:     lowerCamelCase(field.JSONName()),
+			Required: field.Required,
+			Input:    true,
+			Column:   field.Column,
+			GoName:   field.Name,
+			GoType:   field.Type,
+		})
+	}
+	if c.Versioned {
+		fields = append(fields, GraphQLField{Name: "version", GoName: "Version", GoType: "uint"})
+	}
+
+	var result []GraphQLField
+	for _, field := range fields {
+		field.Type = graphQLType(strings.TrimPrefix(field.GoType, "*"))
+		if field.Type == "" {
+			continue
+		}
+		field.Optional = strings.HasPrefix(field.GoType, "*")
+		result = append(result, field)
+	}
+	return result
+}
+
+// InputFields 出现在 XxxInput 中的字段
+func (c ModelConfig) InputFields() []GraphQLField {
+	var fields []GraphQLField
+	for _, field := range c.GraphQLFields() {
+		if field.Input {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// PatchFields 出现在 XxxPatch 中的字段，与 XxxInput 相同但都可以省略，未提供的字段不修改
+func (c ModelConfig) PatchFields() []GraphQLField {
+	fields := c.InputFields()
+	for i := range fields {
+		fields[i].Required = false
+	}
+	return fields
+}
+
+// GraphQLObject 生成项目中包装模型、提供GraphQL字段解析方法的类型名
+// 按模型名命名而不是模块名，关联字段可以直接引用关联模型的类型
+func (c ModelConfig) GraphQLObject() string {
+	return defaultModuleName(c.ModelName) + "Object"
+}
+
+// OutputType 字段在GraphQL类型中的类型，非指针字段不可为 null
+func (f GraphQLField) OutputType() string {
+	if f.Optional {
+		return f.Type
+	}
+	return f.Type + "!"
+}
+
+// InputType 字段在 XxxInput 中的类型，只有必填字段不可为 null，未提供的字段为零值
+func (f GraphQLField) InputType() string {
+	if f.inputNonNull() {
+		return f.Type + "!"
+	}
+	return f.Type
+}
+
+func (f GraphQLField) inputNonNull() bool {
+	return f.Required && !f.Optional
+}
+
+// ResultGoType 字段解析方法的返回类型
+func (f GraphQLField) ResultGoType() string {
+	if f.Optional {
+		return "*" + graphQLGoType(f.Type)
+	}
+	return graphQLGoType(f.Type)
+}
+
+// InputGoType 字段在输入结构体中的类型，可为 null 的字段为指针
+func (f GraphQLField) InputGoType() string {
+	if f.inputNonNull() {
+		return graphQLGoType(f.Type)
+	}
+	return "*" + graphQLGoType(f.Type)
+}
+
+// ToGraphQL 将模型字段 src 转换为解析方法返回值的表达式
+func (f GraphQLField) ToGraphQL(src string) string {
+	baseType := strings.TrimPrefix(f.GoType, "*")
+	goType := graphQLGoType(f.Type)
+	switch {
+	case f.Type == "Time" && f.Optional:
+		return "timePtr(" + src + ")"
+	case f.Type == "Time":
+		return "graphql.Time{Time: " + src + "}"
+	case goType == baseType:
+		return src
+	case f.Optional:
+		return "convertPtr[" + goType + "](" + src + ")"
+	}
+	return goType + "(" + src + ")"
+}
+
+// FromInput 将输入字段 src 转换为模型字段值的表达式
+func (f GraphQLField) FromInput(src string) string {
+	baseType := strings.TrimPrefix(f.GoType, "*")
+	goType := graphQLGoType(f.Type)
+	switch {
+	case f.inputNonNull() && f.Type == "Time":
+		return src + ".Time"
+	case f.inputNonNull() && goType == baseType:
+		return src
+	case f.inputNonNull():
+		return baseType + "(" + src + ")"
+	case f.Optional && f.Type == "Time":
+		return "timeOrNil(" + src + ")"
+	case f.Optional && goType == baseType:
+		return src
+	case f.Optional:
+		return "convertPtr[" + baseType + "](" + src + ")"
+	case f.Type == "Time":
+		return "deref(" + src + ").Time"
+	case goType == baseType:
+		return "deref(" + src + ")"
+	}
+	return baseType + "(deref(" + src + "))"
+}
+
+// GraphQLName 关联字段在GraphQL中的名称
+func (r Relation) GraphQLName() string {
+	return lowerCamelCase(toSnakeCase(r.Name))
+}
+
+// GraphQLType 关联字段在GraphQL中的类型，belongs_to 可为 null，其余为列表
+func (r Relation) GraphQLType() string {
+	if r.Type == BelongsTo {
+		return r.Model
+	}
+	return "[" + r.Model + "!]!"
+}
+
+// GraphQLObject 关联模型在生成项目中的GraphQL类型名，与 ModelConfig.GraphQLObject 一致
+func (r Relation) GraphQLObject() string {
+	return defaultModuleName(r.Model) + "Object"
+}
+
+// 下划线命名转换为小驼峰命名，如 created_at -> createdAt
+func lowerCamelCase(name string) string {
+	parts := strings.Split(name, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,503 @@
+package tableutil
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/liam/go_web_quick_start/scripts/generator/pkg/fileutil"
+	"golang.org/x/tools/go/ast/astutil"
+	"gopkg.in/yaml.v3"
+)
+
+// gRPC服务注册函数名，与grpc_server_base.tmpl保持一致
+const registerServicesFunc = "RegisterServices"
+
+// ErrGRPCRegistered 模块的gRPC服务已注册
+var ErrGRPCRegistered = errors.New("已注册到 " + registerServicesFunc)
+
+// LoadGRPCPort 读取项目config.yaml中的 grpc.port，为空表示项目未启用gRPC
+func LoadGRPCPort(path string) string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+
+	var config struct {
+		GRPC struct {
+			Port string `yaml:"port"`
+		} `yaml:"grpc"`
+	}
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return ""
+	}
+	return config.GRPC.Port
+}
+
+// GenerateGRPC 生成模型的 proto/xxx/v1/xxx.proto 和 internal/grpcserver/xxx.go，并注册到 grpcserver.RegisterServices
+// 已安装buf时执行 buf generate proto 生成 pb 包，否则提示手动执行
+func GenerateGRPC(projectRoot, templatesDir string, config ModelConfig) error {
+	name := strings.ToLower(config.ModelName)
+	protoPath := filepath.Join(projectRoot, "proto", name, "v1", name+".proto")
+	existing, exists, err := fileutil.ReadFile(protoPath)
+	if err != nil {
+		return fmt.Errorf("读取文件 %s 失败: %v", protoPath, err)
+	}
+	if exists {
+		if config.ProtoLayout, err = ParseProtoLayout(existing, config.ModelName); err != nil {
+			return fmt.Errorf("解析 %s 失败: %v", protoPath, err)
+		}
+	}
+	if err := GenerateFileFromTemplate(protoPath, templatesDir, "proto.tmpl", config); err != nil {
+		return err
+	}
+
+	serverDir := filepath.Join(projectRoot, "internal", "grpcserver")
+	serverPath := filepath.Join(serverDir, strings.ToLower(config.ModuleName)+".go")
+	if err := GenerateFileFromTemplate(serverPath, templatesDir, "grpc_server.tmpl", config); err != nil {
+		return err
+	}
+
+	err = UpdateGRPCServer(filepath.Join(serverDir, "server.go"), templatesDir, config)
+	if errors.Is(err, ErrGRPCRegistered) {
+		fmt.Printf("提示: %v，跳过注册gRPC服务\n", err)
+	} else if err != nil {
+		return err
+	}
+
+	if fileutil.Preview() {
+		return nil
+	}
+	if _, err := exec.LookPath("buf"); err != nil {
+		fmt.Println("提示: 未找到buf，请在项目根目录执行 buf generate proto 生成gRPC代码")
+		return nil
+	}
+	cmd := exec.Command("buf", "generate", "proto")
+	cmd.Dir = projectRoot
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		// 通常是未安装protoc-gen-go、protoc-gen-go-grpc，不影响已生成的文件
+		fmt.Printf("警告: 执行 buf generate 失败: %v，请安装插件后在项目根目录重新执行 buf generate proto\n", err)
+	}
+	return nil
+}
+
+// UpdateGRPCServer 将模型的gRPC服务注册到 grpcserver.RegisterServices
+// 文件不存在时先由grpc_server_base.tmpl创建，再在函数末尾插入grpc_register.tmpl生成的代码
+func UpdateGRPCServer(filePath, templatesDir string, config ModelConfig) error {
+	content, exists, err := fileutil.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("读取文件 %s 失败: %v", filePath, err)
+	}
+	if !exists {
+		if content, err = executeTemplate(templatesDir, "grpc_server_base.tmpl", config); err != nil {
+			return err
+		}
+	}
+	snippet, err := executeTemplate(templatesDir, "grpc_register.tmpl", config)
+	if err != nil {
+		return err
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filePath, content, parser.ParseComments)
+	if err != nil {
+		return fmt.Errorf("解析 %s 失败: %v", filePath, err)
+	}
+	fn := findFunc(file, registerServicesFunc)
+	if fn == nil || fn.Body == nil {
+		return fmt.Errorf("%s 中未找到 %s 函数", filePath, registerServicesFunc)
+	}
+	serverFunc := "New" + config.ModelName + "Server"
+	if callsFunc(fn.Body, serverFunc) {
+		return fmt.Errorf("%s %w", serverFunc, ErrGRPCRegistered)
+	}
+
+	// 插入到函数末尾，已有注册代码时空一行
+	text := string(snippet)
+	if len(fn.Body.List) > 0 {
+		text = "\n" + text
+	}
+	offset := fset.Position(fn.Body.Rbrace).Offset
+	updated := applyEdits(content, []textEdit{{offset: offset, text: text}})
+
+	// 确保导入了dao包、service包和模型的pb包
+	file, err = parser.ParseFile(fset, filePath, updated, parser.ParseComments)
+	if err != nil {
+		return fmt.Errorf("合并后的 %s 无效: %v", filePath, err)
+	}
+	astutil.AddImport(fset, file, config.ProjectImport+"/internal/dao")
+	astutil.AddImport(fset, file, config.ProjectImport+"/internal/service")
+	astutil.AddNamedImport(fset, file, config.ProtoGoPackage(), config.ProtoGoImport())
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return fmt.Errorf("格式化 %s 失败: %v", filePath, err)
+	}
+	return fileutil.WriteFile(filePath, buf.Bytes())
+}
+
+// ProtoPackage 模型的proto包名，如 post.v1
+func (c ModelConfig) ProtoPackage() string {
+	return strings.ToLower(c.ModelName) + ".v1"
+}
+
+// ProtoGoPackage 生成的pb包在Go代码中的包名，如 postv1
+func (c ModelConfig) ProtoGoPackage() string {
+	return strings.ToLower(c.ModelName) + "v1"
+}
+
+// ProtoGoImport 生成的pb包的导入路径，与buf.gen.yaml的输出目录一致
+func (c ModelConfig) ProtoGoImport() string {
+	name := strings.ToLower(c.ModelName)
+	return c.ProjectImport + "/pb/" + name + "/v1"
+}
+
+// ProtoResource 请求消息中模型字段的名称，如 order_item
+func (c ModelConfig) ProtoResource() string {
+	return toSnakeCase(c.ModelName)
+}
+
+// ProtoField proto消息中的字段
+type ProtoField struct {
+	Name     string // 字段名，与JSON字段名一致
+	Type     string // proto类型
+	Number   int    // 字段编号
+	Optional bool   // 可空字段使用 optional
+	GoName   string // 模型结构体中的字段名
+	GoType   string // 模型结构体中的字段类型
+}
+
+// Go类型对应的proto类型，无法对应的类型返回空
+func protoType(goType string) string {
+	switch goType {
+	case "string", "bool", "int32", "int64", "uint32", "uint64":
+		return goType
+	case "int":
+		return "int64"
+	case "uint":
+		return "uint64"
+	case "int8", "int16":
+		return "int32"
+	case "uint8", "uint16":
+		return "uint32"
+	case "float32":
+		return "float"
+	case "float64":
+		return "double"
+	case "time.Time":
+		return "google.protobuf.Timestamp"
+	case "[]byte":
+		return "bytes"
+	}
+	return ""
+}
+
+// proto类型在生成的Go代码中的类型
+func protoGoType(protoType string) string {
+	switch protoType {
+	case "float":
+		return "float32"
+	case "double":
+		return "float64"
+	case "bytes":
+		return "[]byte"
+	}
+	return protoType
+}
+
+// IDProtoType 主键的proto类型
+func (c ModelConfig) IDProtoType() string {
+	return protoType(c.idType())
+}
+
+// 固定编号的公共字段，模型字段从 firstProtoFieldNumber 开始编号
+// version 的编号始终保留，之后启用乐观锁时不影响已有字段
+var fixedProtoNumbers = map[string]int{"id": 1, "created_at": 2, "updated_at": 3, "version": 4}
+
+const firstProtoFieldNumber = 5
+
+// ProtoFields proto消息的字段：id、created_at、updated_at、启用乐观锁时的 version、模型字段
+// 无法对应proto类型的字段不生成；已有proto文件时沿用其中的编号，见 ProtoLayout
+func (c ModelConfig) ProtoFields() []ProtoField {
+	fields := []ProtoField{
+		{Name: "id", GoName: "ID", GoType: c.idType()},
+		{Name: "created_at", GoName: "CreatedAt", GoType: "time.Time"},
+		{Name: "updated_at", GoName: "UpdatedAt", GoType: "time.Time"},
+	}
+	if c.Versioned {
+		fields = append(fields, ProtoField{Name: "version", GoName: "Version", GoType: "uint"})
+	}
+	for _, field := range c.Fields {
+		fields = append(fields, ProtoField{Name: field.JSONName(), GoName: field.Name, GoType: field.Type})
+	}
+
+	var result []ProtoField
+	for _, field := range fields {
+		baseType := strings.TrimPrefix(field.GoType, "*")
+		field.Type = protoType(baseType)
+		if field.Type == "" {
+			continue
+		}
+		field.Optional = strings.HasPrefix(field.GoType, "*")
+		result = append(result, field)
+	}
+	c.numberProtoFields(result)
+	return result
+}
+
+// 给字段分配编号：已有proto文件中同名同类型的字段沿用原编号，公共字段使用固定编号，
+// 其余字段使用未占用也未保留的最小编号
+func (c ModelConfig) numberProtoFields(fields []ProtoField) {
+	layout := c.ProtoLayout
+	if layout == nil {
+		layout = &ProtoLayout{}
+	}
+	taken := make(map[int]bool)
+	for _, slot := range layout.Fields {
+		taken[slot.Number] = true
+	}
+	for _, number := range layout.ReservedNumbers {
+		taken[number] = true
+	}
+	used := make(map[int]bool)
+	for number := range taken {
+		used[number] = true
+	}
+	for _, number := range fixedProtoNumbers {
+		used[number] = true
+	}
+
+	next := firstProtoFieldNumber
+	for i, field := range fields {
+		slot, ok := layout.Fields[field.Name]
+		if ok && slot.Type == field.Type {
+			fields[i].Number = slot.Number
+			continue
+		}
+		if number, fixed := fixedProtoNumbers[field.Name]; fixed && !ok && !taken[number] {
+			fields[i].Number = number
+			continue
+		}
+		for used[next] {
+			next++
+		}
+		fields[i].Number = next
+		used[next] = true
+	}
+}
+
+// ProtoReserved 消息中需要保留的编号和字段名：已有proto文件中保留的，以及已删除或类型已改变的字段
+// 保留后的编号和字段名不会再被分配，避免旧客户端按原编号解析出错误的数据
+func (c ModelConfig) ProtoReserved() (numbers []int, names []string) {
+	if c.ProtoLayout == nil {
+		return nil, nil
+	}
+	current := make(map[string]ProtoField)
+	inUse := make(map[int]bool)
+	for _, field := range c.ProtoFields() {
+		current[field.Name] = field
+		inUse[field.Number] = true
+	}
+
+	numbers = append(numbers, c.ProtoLayout.ReservedNumbers...)
+	for _, name := range c.ProtoLayout.ReservedNames {
+		if _, ok := current[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	for name, slot := range c.ProtoLayout.Fields {
+		if !inUse[slot.Number] {
+			numbers = append(numbers, slot.Number)
+		}
+		if _, ok := current[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Ints(numbers)
+	sort.Strings(names)
+	return numbers, names
+}
+
+// ProtoReservedNumbers proto中 reserved 语句的编号部分，如 6, 8
+func (c ModelConfig) ProtoReservedNumbers() string {
+	numbers, _ := c.ProtoReserved()
+	parts := make([]string, len(numbers))
+	for i, number := range numbers {
+		parts[i] = strconv.Itoa(number)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ProtoReservedNames proto中 reserved 语句的字段名部分，如 "title", "body"
+func (c ModelConfig) ProtoReservedNames() string {
+	_, names := c.ProtoReserved()
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = strconv.Quote(name)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ProtoLayout 已有proto文件中模型消息的字段编号，重新生成时沿用以保持兼容
+type ProtoLayout struct {
+	Fields          map[string]ProtoSlot // 字段名对应的编号和类型
+	ReservedNumbers []int
+	ReservedNames   []string
+}
+
+// ProtoSlot 已分配的字段编号
+type ProtoSlot struct {
+	Number int
+	Type   string
+}
+
+var (
+	protoFieldPattern    = regexp.MustCompile(`^(?:optional\s+|repeated\s+)?([\w.]+)\s+(\w+)\s*=\s*(\d+)\s*;`)
+	protoReservedPattern = regexp.MustCompile(`^reserved\s+(.+);`)
+)
+
+// ParseProtoLayout 解析proto内容中消息 message 的字段编号和 reserved 语句，消息不存在时返回 nil
+func ParseProtoLayout(content []byte, message string) (*ProtoLayout, error) {
+	lines := strings.Split(string(content), "\n")
+	start := -1
+	for i, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "message" && strings.TrimSuffix(fields[1], "{") == message {
+			start = i + 1
+			break
+		}
+	}
+	if start < 0 {
+		return nil, nil
+	}
+
+	layout := &ProtoLayout{Fields: make(map[string]ProtoSlot)}
+	for i := start; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if j := strings.Index(line, "//"); j >= 0 {
+			line = strings.TrimSpace(line[:j])
+		}
+		if line == "}" {
+			return layout, nil
+		}
+		if m := protoReservedPattern.FindStringSubmatch(line); m != nil {
+			if err := layout.addReserved(m[1]); err != nil {
+				return nil, fmt.Errorf("第%d行: %v", i+1, err)
+			}
+			continue
+		}
+		if m := protoFieldPattern.FindStringSubmatch(line); m != nil {
+			number, _ := strconv.Atoi(m[3])
+			layout.Fields[m[2]] = ProtoSlot{Number: number, Type: m[1]}
+		}
+	}
+	return nil, fmt.Errorf("消息 %s 缺少结束的 }", message)
+}
+
+// 解析 reserved 语句的内容，支持编号、编号范围(to)和带引号的字段名
+func (l *ProtoLayout) addReserved(list string) error {
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if name, err := strconv.Unquote(item); err == nil {
+			l.ReservedNames = append(l.ReservedNames, name)
+			continue
+		}
+		from, to, isRange := strings.Cut(item, " to ")
+		first, err := strconv.Atoi(strings.TrimSpace(from))
+		if err != nil {
+			return fmt.Errorf("无效的保留编号 %q", item)
+		}
+		last := first
+		if isRange {
+			if last, err = strconv.Atoi(strings.TrimSpace(to)); err != nil || last < first {
+				return fmt.Errorf("无效的保留编号 %q", item)
+			}
+		}
+		for number := first; number <= last; number++ {
+			l.ReservedNumbers = append(l.ReservedNumbers, number)
+		}
+	}
+	return nil
+}
+
+// ProtoGoName 字段在生成的pb结构体中的名称
+func (f ProtoField) ProtoGoName() string {
+	return goCamelCase(f.Name)
+}
+
+// ToProto 将模型字段 src 转换为pb字段值的表达式
+func (f ProtoField) ToProto(src string) string {
+	baseType := strings.TrimPrefix(f.GoType, "*")
+	switch {
+	case f.Type == "google.protobuf.Timestamp" && f.Optional:
+		return "timestampOrNil(" + src + ")"
+	case f.Type == "google.protobuf.Timestamp":
+		return "timestamppb.New(" + src + ")"
+	case protoGoType(f.Type) == baseType:
+		return src
+	case f.Optional:
+		return "convertPtr[" + protoGoType(f.Type) + "](" + src + ")"
+	}
+	return protoGoType(f.Type) + "(" + src + ")"
+}
+
+// FromProto 将pb字段值 src 转换为模型字段值的表达式
+func (f ProtoField) FromProto(src string) string {
+	baseType := strings.TrimPrefix(f.GoType, "*")
+	switch {
+	case f.Type == "google.protobuf.Timestamp" && f.Optional:
+		return "timeOrNil(" + src + ")"
+	case f.Type == "google.protobuf.Timestamp":
+		return "asTime(" + src + ")"
+	case protoGoType(f.Type) == baseType:
+		return src
+	case f.Optional:
+		return "convertPtr[" + baseType + "](" + src + ")"
+	}
+	return baseType + "(" + src + ")"
+}
+
+// IDFromProto 将pb中的主键 src 转换为模型主键类型的表达式
+func (c ModelConfig) IDFromProto(src string) string {
+	return ProtoField{Type: c.IDProtoType(), GoType: c.idType()}.FromProto(src)
+}
+
+// 与protoc-gen-go相同的字段名转换规则：下划线后的小写字母大写，数字保持不变
+func goCamelCase(s string) string {
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '_' && i == 0:
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(s) && isASCIILower(s[i+1]):
+		case c >= '0' && c <= '9':
+			b = append(b, c)
+		default:
+			if isASCIILower(c) {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			for ; i+1 < len(s) && isASCIILower(s[i+1]); i++ {
+				b = append(b, s[i+1])
+			}
+		}
+	}
+	return string(b)
+}
+
+func isASCIILower(c byte) bool {
+	return c >= 'a' && c <= 'z'
+}
@@ -0,0 +1,126 @@
+package tableutil
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// 按 GenerateGRPC 的方式生成proto：已有内容时先读取其中的字段编号
+func renderProto(t *testing.T, config ModelConfig, previous []byte) []byte {
+	t.Helper()
+	if previous != nil {
+		layout, err := ParseProtoLayout(previous, config.ModelName)
+		if err != nil {
+			t.Fatalf("解析已有proto失败: %v", err)
+		}
+		config.ProtoLayout = layout
+	}
+	content, err := executeTemplate("", "proto.tmpl", config)
+	if err != nil {
+		t.Fatalf("生成proto失败: %v", err)
+	}
+	return content
+}
+
+// 生成的proto中模型消息的字段编号
+func protoNumbers(t *testing.T, content []byte) map[string]int {
+	t.Helper()
+	layout, err := ParseProtoLayout(content, "Post")
+	if err != nil || layout == nil {
+		t.Fatalf("生成的proto中没有 Post 消息: %v\n%s", err, content)
+	}
+	numbers := make(map[string]int)
+	for name, slot := range layout.Fields {
+		numbers[name] = slot.Number
+	}
+	return numbers
+}
+
+func TestProtoFieldNumbersStable(t *testing.T) {
+	config := ModelConfig{
+		ProjectImport: "example.com/app", TableName: "posts", ModelName: "Post", ModuleName: "post",
+		Fields: []Field{{Name: "Title", Type: "string", Column: "title"}, {Name: "Body", Type: "string", Column: "body"}},
+	}
+	first := renderProto(t, config, nil)
+	want := map[string]int{"id": 1, "created_at": 2, "updated_at": 3, "title": 5, "body": 6}
+	if got := protoNumbers(t, first); !reflect.DeepEqual(got, want) {
+		t.Fatalf("首次生成的编号 = %v, 期望 %v", got, want)
+	}
+
+	// 在开头新增字段并启用乐观锁，已有字段的编号不变
+	config.Fields = append([]Field{{Name: "Summary", Type: "*string", Column: "summary"}}, config.Fields...)
+	config.Versioned = true
+	second := renderProto(t, config, first)
+	want = map[string]int{"id": 1, "created_at": 2, "updated_at": 3, "version": 4, "title": 5, "body": 6, "summary": 7}
+	if got := protoNumbers(t, second); !reflect.DeepEqual(got, want) {
+		t.Fatalf("新增字段后的编号 = %v, 期望 %v", got, want)
+	}
+
+	// 删除字段后其编号和名称被保留，之后新增的字段不会复用
+	config.Fields = []Field{{Name: "Title", Type: "string", Column: "title"}, {Name: "Summary", Type: "*string", Column: "summary"}}
+	third := renderProto(t, config, second)
+	if !strings.Contains(string(third), `reserved 6;`) || !strings.Contains(string(third), `reserved "body";`) {
+		t.Fatalf("删除字段后没有保留编号和名称:\n%s", third)
+	}
+	config.Fields = append(config.Fields, Field{Name: "Slug", Type: "string", Column: "slug"})
+	fourth := renderProto(t, config, third)
+	want = map[string]int{"id": 1, "created_at": 2, "updated_at": 3, "version": 4, "title": 5, "summary": 7, "slug": 8}
+	if got := protoNumbers(t, fourth); !reflect.DeepEqual(got, want) {
+		t.Fatalf("删除后新增字段的编号 = %v, 期望 %v", got, want)
+	}
+	if !strings.Contains(string(fourth), `reserved 6;`) {
+		t.Fatalf("已保留的编号在重新生成后丢失:\n%s", fourth)
+	}
+
+	// 字段类型改变时使用新编号，原编号被保留
+	config.Fields[0].Type = "int64"
+	fifth := renderProto(t, config, fourth)
+	if got := protoNumbers(t, fifth)["title"]; got != 9 {
+		t.Errorf("类型改变后 title 的编号 = %d, 期望 9", got)
+	}
+	if !strings.Contains(string(fifth), `reserved 5, 6;`) {
+		t.Errorf("类型改变后没有保留原编号:\n%s", fifth)
+	}
+}
+
+func TestParseProtoLayout(t *testing.T) {
+	content := []byte(`syntax = "proto3";
+
+message Post {
+  reserved 2, 9 to 11;
+  reserved "legacy";
+  uint64 id = 1; // 主键
+  optional google.protobuf.Timestamp published_at = 12;
+}
+
+message Filter {
+  string column = 1;
+}
+`)
+	layout, err := ParseProtoLayout(content, "Post")
+	if err != nil {
+		t.Fatalf("ParseProtoLayout() error = %v", err)
+	}
+	want := &ProtoLayout{
+		Fields: map[string]ProtoSlot{
+			"id":           {Number: 1, Type: "uint64"},
+			"published_at": {Number: 12, Type: "google.protobuf.Timestamp"},
+		},
+		ReservedNumbers: []int{2, 9, 10, 11},
+		ReservedNames:   []string{"legacy"},
+	}
+	if !reflect.DeepEqual(layout, want) {
+		t.Errorf("ParseProtoLayout() = %+v, 期望 %+v", layout, want)
+	}
+
+	if layout, err := ParseProtoLayout(content, "Comment"); layout != nil || err != nil {
+		t.Errorf("消息不存在时应返回 nil, got %+v, %v", layout, err)
+	}
+	if _, err := ParseProtoLayout([]byte("message Post {\n  reserved 3 to x;\n}\n"), "Post"); err == nil {
+		t.Error("无效的 reserved 应返回错误")
+	}
+	if _, err := ParseProtoLayout([]byte("message Post {\n  uint64 id = 1;\n"), "Post"); err == nil {
+		t.Error("缺少 } 应返回错误")
+	}
+}
@@ -0,0 +1,255 @@
+package tableutil
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/driver/sqlserver"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// DatabaseConfig 项目config.yaml中的数据库配置
+type DatabaseConfig struct {
+	Type     string `yaml:"type"`
+	Host     string `yaml:"host"`
+	Port     string `yaml:"port"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	DBName   string `yaml:"dbname"`
+}
+
+// TableSchema 从数据库读取的表结构
+type TableSchema struct {
+	PrimaryKey string // 主键列名
+	IDType     string // 主键对应的Go类型
+	Fields     []Field
+}
+
+// 模型模板中已固定生成的列
+var builtinColumns = map[string]bool{
+	"created_at": true,
+	"updated_at": true,
+	"deleted_at": true,
+}
+
+// LoadDatabaseConfig 读取项目config.yaml中的database配置
+func LoadDatabaseConfig(path string) (*DatabaseConfig, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取配置文件失败: %v", err)
+	}
+
+	var config struct {
+		Database DatabaseConfig `yaml:"database"`
+	}
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return nil, fmt.Errorf("解析配置文件 %s 失败: %v", path, err)
+	}
+	if config.Database.Type == "" {
+		return nil, fmt.Errorf("配置文件 %s 中缺少 database.type", path)
+	}
+	return &config.Database, nil
+}
+
+// OpenDatabase 按项目配置连接数据库，DSN格式与生成项目的pkg/database保持一致
+func OpenDatabase(config *DatabaseConfig) (*gorm.DB, error) {
+	var dialector gorm.Dialector
+
+	switch config.Type {
+	case "mysql":
+		dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+			config.Username, config.Password, config.Host, config.Port, config.DBName)
+		dialector = mysql.Open(dsn)
+	case "postgres":
+		dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=Asia/Shanghai",
+			config.Host, config.Username, config.Password, config.DBName, config.Port)
+		dialector = postgres.Open(dsn)
+	case "sqlite":
+		dialector = sqlite.Open(config.DBName)
+	case "sqlserver":
+		dsn := fmt.Sprintf("sqlserver://%s:%s@%s:%s?database=%s",
+			config.Username, config.Password, config.Host, config.Port, config.DBName)
+		dialector = sqlserver.Open(dsn)
+	default:
+		return nil, fmt.Errorf("不支持从 %s 数据库读取表结构", config.Type)
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("连接数据库失败: %v", err)
+	}
+	return db, nil
+}
+
+// ReadTableSchema 读取表的列名、类型、可空性、默认值、注释和主键
+func ReadTableSchema(db *gorm.DB, tableName string) (*TableSchema, error) {
+	if !db.Migrator().HasTable(tableName) {
+		return nil, fmt.Errorf("表 %s 不存在", tableName)
+	}
+
+	columnTypes, err := db.Migrator().ColumnTypes(tableName)
+	if err != nil {
+		return nil, fmt.Errorf("读取表 %s 的列信息失败: %v", tableName, err)
+	}
+
+	schema := &TableSchema{}
+	for _, column := range columnTypes {
+		if isPrimaryKey, ok := column.PrimaryKey(); ok && isPrimaryKey && schema.PrimaryKey == "" {
+			schema.PrimaryKey = column.Name()
+			schema.IDType = columnGoType(column, false)
+		}
+	}
+
+	// 部分驱动不返回主键信息，按约定回退到id列
+	if schema.PrimaryKey == "" {
+		for _, column := range columnTypes {
+			if strings.EqualFold(column.Name(), "id") {
+				schema.PrimaryKey = column.Name()
+				schema.IDType = columnGoType(column, false)
+				break
+			}
+		}
+	}
+	if schema.PrimaryKey == "" {
+		return nil, fmt.Errorf("表 %s 没有主键", tableName)
+	}
+
+	for _, column := range columnTypes {
+		name := column.Name()
+		if name == schema.PrimaryKey || builtinColumns[strings.ToLower(name)] {
+			continue
+		}
+		schema.Fields = append(schema.Fields, columnField(column))
+	}
+	return schema, nil
+}
+
+// 将数据库列转换为字段定义
+func columnField(column gorm.ColumnType) Field {
+	name := column.Name()
+	nullable, _ := column.Nullable()
+
+	field := Field{
+		Name:     toCamelCase(name),
+		Type:     columnGoType(column, nullable),
+		Column:   name,
+		NotNull:  !nullable,
+		Nullable: nullable,
+	}
+	// 部分驱动解析DDL时会截断形如decimal(10,2)的类型，括号不完整时不使用
+	if columnType, ok := column.ColumnType(); ok && columnType != "" && strings.Count(columnType, "(") == strings.Count(columnType, ")") {
+		field.ColumnType = columnType
+	} else if length, ok := column.Length(); ok && length > 0 && isStringType(column.DatabaseTypeName()) {
+		field.ColumnType = fmt.Sprintf("%s(%d)", strings.ToLower(column.DatabaseTypeName()), length)
+	}
+	if length, ok := column.Length(); ok && length > 0 && isStringType(column.DatabaseTypeName()) {
+		field.Size = int(length)
+	}
+	if unique, ok := column.Unique(); ok && unique {
+		field.Unique = true
+	}
+	if defaultValue, ok := column.DefaultValue(); ok && defaultValue != "" {
+		field.Default = defaultValue
+	}
+	field.Comment, _ = column.Comment()
+	return field
+}
+
+// 数据库类型名对应的Go类型，按完整类型名匹配，未列出的类型（如 interval、point、json）使用 string
+var goTypes = map[string]string{
+	"bigint": "int64", "int8": "int64", "bigserial": "int64", "serial8": "int64", "number": "int64",
+	"int": "int", "integer": "int", "mediumint": "int", "smallint": "int", "tinyint": "int",
+	"int2": "int", "int4": "int", "serial": "int", "serial4": "int", "smallserial": "int", "serial2": "int",
+	"bool": "bool", "boolean": "bool", "bit": "bool",
+	"decimal": "float64", "numeric": "float64", "float": "float64", "float4": "float64", "float8": "float64",
+	"double": "float64", "double precision": "float64", "real": "float64", "money": "float64", "smallmoney": "float64",
+	"date": "time.Time", "datetime": "time.Time", "datetime2": "time.Time", "smalldatetime": "time.Time",
+	"datetimeoffset": "time.Time", "timestamp": "time.Time", "timestamptz": "time.Time", "time": "time.Time",
+	"timetz": "time.Time", "timestamp with time zone": "time.Time", "timestamp without time zone": "time.Time",
+	"time with time zone": "time.Time", "time without time zone": "time.Time",
+	"blob": "[]byte", "tinyblob": "[]byte", "mediumblob": "[]byte", "longblob": "[]byte",
+	"bytea": "[]byte", "binary": "[]byte", "varbinary": "[]byte", "image": "[]byte",
+}
+
+// 列的Go类型，MySQL的 tinyint(1) 为布尔值，bit(n) 只有 n 为1时为布尔值，其余为 []byte
+func columnGoType(column gorm.ColumnType, nullable bool) string {
+	columnType, _ := column.ColumnType()
+	columnType = strings.ToLower(strings.TrimSpace(columnType))
+	if strings.HasPrefix(columnType, "tinyint(1)") {
+		return goType("bool", nullable)
+	}
+	var width int
+	if _, err := fmt.Sscanf(columnType, "bit(%d)", &width); err == nil && width > 1 {
+		return "[]byte"
+	}
+	return goType(column.DatabaseTypeName(), nullable)
+}
+
+// 数据库类型转换为Go类型，可空列使用指针
+// 类型名中的长度、精度和 unsigned 不影响匹配，如 decimal(10,2)、int unsigned
+func goType(dbType string, nullable bool) string {
+	t := strings.ToLower(strings.TrimSpace(dbType))
+	if i := strings.Index(t, "("); i >= 0 {
+		t = strings.TrimSpace(t[:i])
+	}
+	t = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(t, "unsigned "), " unsigned"))
+
+	typ, ok := goTypes[t]
+	if !ok {
+		typ = "string"
+	}
+	if nullable && typ != "[]byte" {
+		return "*" + typ
+	}
+	return typ
+}
+
+// 去掉会破坏结构体标签的字符
+func tagValue(value string) string {
+	return strings.NewReplacer(`"`, "'", "`", "'", ";", ",", "\n", " ").Replace(value)
+}
+
+// 是否为字符串列类型
+func isStringType(dbType string) bool {
+	t := strings.ToLower(dbType)
+	return strings.Contains(t, "char") || strings.Contains(t, "text")
+}
+
+// 下划线命名转换为Go的驼峰命名，如 user_id -> UserID
+func toCamelCase(name string) string {
+	var builder strings.Builder
+	for _, part := range strings.Split(strings.ToLower(name), "_") {
+		if part == "" {
+			continue
+		}
+		if part == "id" || part == "url" || part == "ip" {
+			builder.WriteString(strings.ToUpper(part))
+			continue
+		}
+		builder.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return builder.String()
+}
+
+// 读取项目go.mod中的模块名
+func readModuleName(goModPath string) string {
+	content, err := os.ReadFile(goModPath)
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "module ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "module "))
+		}
+	}
+	return ""
+}
@@ -0,0 +1,187 @@
+package tableutil
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// 字段中由表结构决定的属性
+type columnInfo struct {
+	Type       string
+	ColumnType string
+	NotNull    bool
+	Nullable   bool
+	Unique     bool
+	Size       int
+	Default    string
+}
+
+// 创建使用SQLite数据库的项目目录，执行建表语句后返回 --from-db 的选项
+func newSQLiteProject(t *testing.T, statements ...string) (Options, string) {
+	t.Helper()
+	root := t.TempDir()
+	dbPath := filepath.Join(root, "app.db")
+	config := "database:\n  type: sqlite\n  dbname: " + dbPath + "\n"
+	if err := os.MkdirAll(filepath.Join(root, "config"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "config", "config.yaml"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/app\n\ngo 1.20\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := OpenDatabase(&DatabaseConfig{Type: "sqlite", DBName: dbPath})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			t.Fatalf("执行 %q 失败: %v", statement, err)
+		}
+	}
+	if sqlDB, err := db.DB(); err == nil {
+		sqlDB.Close()
+	}
+	return Options{FromDB: true}, root
+}
+
+func TestReadModelConfigFromDB(t *testing.T) {
+	opts, root := newSQLiteProject(t, `CREATE TABLE articles (
+		id integer PRIMARY KEY AUTOINCREMENT,
+		title varchar(200) NOT NULL,
+		slug varchar(100) UNIQUE,
+		views bigint NOT NULL DEFAULT 0,
+		price decimal(10,2),
+		published boolean DEFAULT false,
+		is_top tinyint(1) NOT NULL DEFAULT 0,
+		flags bit(8),
+		active bit(1),
+		body text,
+		cover blob,
+		published_at datetime,
+		version integer NOT NULL DEFAULT 1,
+		created_at datetime,
+		updated_at datetime,
+		deleted_at datetime
+	)`)
+	opts.Table = "articles"
+
+	config, err := readModelConfigFromDB(opts, root)
+	if err != nil {
+		t.Fatalf("readModelConfigFromDB() error = %v", err)
+	}
+	if config.TableName != "articles" || config.ModelName != "Article" || config.ProjectImport != "example.com/app" {
+		t.Errorf("表名、模型名或模块名错误: %+v", config)
+	}
+	if config.ID != "int" || config.DBType != "sqlite" {
+		t.Errorf("ID = %q, DBType = %q, 期望 int, sqlite", config.ID, config.DBType)
+	}
+	if !config.Versioned {
+		t.Error("整数 version 列应作为乐观锁版本号")
+	}
+
+	want := map[string]columnInfo{
+		"title":        {Type: "string", ColumnType: "varchar(200)", NotNull: true, Size: 200},
+		"slug":         {Type: "*string", ColumnType: "varchar(100)", Nullable: true, Unique: true, Size: 100},
+		"views":        {Type: "int64", ColumnType: "bigint", NotNull: true, Default: "0"},
+		"price":        {Type: "*float64", Nullable: true},
+		"published":    {Type: "*bool", ColumnType: "boolean", Nullable: true, Default: "false"},
+		"is_top":       {Type: "bool", ColumnType: "tinyint(1)", NotNull: true, Default: "0"},
+		"flags":        {Type: "[]byte", ColumnType: "bit(8)", Nullable: true},
+		"active":       {Type: "*bool", ColumnType: "bit(1)", Nullable: true},
+		"body":         {Type: "*string", ColumnType: "text", Nullable: true},
+		"cover":        {Type: "[]byte", ColumnType: "blob", Nullable: true},
+		"published_at": {Type: "*time.Time", ColumnType: "datetime", Nullable: true},
+	}
+	got := make(map[string]columnInfo)
+	for _, field := range config.Fields {
+		got[field.Column] = columnInfo{
+			Type: field.Type, ColumnType: field.ColumnType, NotNull: field.NotNull, Nullable: field.Nullable,
+			Unique: field.Unique, Size: field.Size, Default: field.Default,
+		}
+	}
+	for column, info := range want {
+		if got[column] != info {
+			t.Errorf("列 %s = %+v, 期望 %+v", column, got[column], info)
+		}
+	}
+	if !reflect.DeepEqual(sortedKeys(got), sortedKeys(want)) {
+		t.Errorf("字段 = %v, 期望 %v（不包含 id、version 和时间戳列）", sortedKeys(got), sortedKeys(want))
+	}
+}
+
+func TestReadModelConfigFromDBErrors(t *testing.T) {
+	opts, root := newSQLiteProject(t,
+		`CREATE TABLE codes (code varchar(20) PRIMARY KEY, name text)`,
+		`CREATE TABLE logs (message text)`,
+	)
+	tests := []struct {
+		table   string
+		wantErr string
+	}{
+		{table: "codes", wantErr: "主键列为 code"},
+		{table: "logs", wantErr: "没有主键"},
+		{table: "missing", wantErr: "不存在"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.table, func(t *testing.T) {
+			opts.Table = tt.table
+			_, err := readModelConfigFromDB(opts, root)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("readModelConfigFromDB(%s) error = %v, 期望包含 %q", tt.table, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestColumnGoTypeBit(t *testing.T) {
+	tests := []struct {
+		columnType string
+		nullable   bool
+		want       string
+	}{
+		{columnType: "bit(1)", want: "bool"},
+		{columnType: "bit(1)", nullable: true, want: "*bool"},
+		{columnType: "bit(8)", want: "[]byte"},
+		{columnType: "bit(64)", nullable: true, want: "[]byte"},
+		{columnType: "BIT(10)", want: "[]byte"},
+		{columnType: "tinyint(1)", nullable: true, want: "*bool"},
+		{columnType: "tinyint(4)", want: "int"},
+	}
+	for _, tt := range tests {
+		name := strings.ToLower(tt.columnType[:strings.Index(tt.columnType, "(")])
+		column := fakeColumn{name: name, columnType: tt.columnType}
+		if got := columnGoType(column, tt.nullable); got != tt.want {
+			t.Errorf("columnGoType(%s, %v) = %s, 期望 %s", tt.columnType, tt.nullable, got, tt.want)
+		}
+	}
+}
+
+// 只提供类型信息的列，用于不依赖数据库测试类型映射，其余方法不可调用
+type fakeColumn struct {
+	gormColumn
+	name       string
+	columnType string
+}
+
+func (c fakeColumn) DatabaseTypeName() string   { return c.name }
+func (c fakeColumn) ColumnType() (string, bool) { return c.columnType, true }
+
+type gormColumn = gorm.ColumnType
+
+func sortedKeys(m map[string]columnInfo) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
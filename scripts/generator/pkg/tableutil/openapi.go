@@ -0,0 +1,287 @@
+package tableutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/liam/go_web_quick_start/scripts/generator/pkg/fileutil"
+	"github.com/liam/go_web_quick_start/scripts/generator/templates"
+	"gopkg.in/yaml.v3"
+)
+
+// 响应风格，与生成项目的 response 包保持一致
+const (
+	ResponseEnvelope = "envelope"
+	ResponseBare     = "bare"
+)
+
+// OpenAPI 模板的数据，ResponseStyle 决定响应结构
+type openAPIData struct {
+	ModelConfig
+	ResponseStyle string
+}
+
+// LoadResponseStyle 读取项目config.yaml中的 app.response_style
+// 配置文件不存在或未配置时为 envelope
+func LoadResponseStyle(path string) string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return ResponseEnvelope
+	}
+
+	var config struct {
+		App struct {
+			ResponseStyle string `yaml:"response_style"`
+		} `yaml:"app"`
+	}
+	if err := yaml.Unmarshal(content, &config); err != nil || config.App.ResponseStyle != ResponseBare {
+		return ResponseEnvelope
+	}
+	return ResponseBare
+}
+
+// UpdateOpenAPI 将模型的接口和数据结构写入 OpenAPI 文档
+// 文档不存在时先由openapi.tmpl创建；openapi_paths.tmpl生成的路径和 components.schemas 中的同名项会被替换，其余内容保留
+func UpdateOpenAPI(filePath, templatesDir string, config ModelConfig, responseStyle string) error {
+	data := openAPIData{ModelConfig: config, ResponseStyle: responseStyle}
+
+	content, exists, err := fileutil.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("读取文件 %s 失败: %v", filePath, err)
+	}
+	if !exists {
+		if content, err = executeTemplate(templatesDir, "openapi.tmpl", data); err != nil {
+			return err
+		}
+	}
+	fragment, err := executeTemplate(templatesDir, "openapi_paths.tmpl", data)
+	if err != nil {
+		return err
+	}
+
+	var doc, generated yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return fmt.Errorf("解析 %s 失败: %v", filePath, err)
+	}
+	if err := yaml.Unmarshal(fragment, &generated); err != nil {
+		return fmt.Errorf("解析生成的 OpenAPI 片段失败: %v", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return fmt.Errorf("%s 不是有效的 OpenAPI 文档", filePath)
+	}
+	root := doc.Content[0]
+	generatedRoot := generated.Content[0]
+	resetStyle(generatedRoot)
+
+	// 片段中 paths 对应文档的 paths，schemas 对应文档的 components.schemas
+	paths := mappingValue(root, "paths")
+	schemas := mappingValue(mappingValue(root, "components"), "schemas")
+	mergeMapping(paths, mappingValue(generatedRoot, "paths"))
+	mergeMapping(schemas, mappingValue(generatedRoot, "schemas"))
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return fmt.Errorf("生成 %s 失败: %v", filePath, err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("生成 %s 失败: %v", filePath, err)
+	}
+	return fileutil.WriteFile(filePath, buf.Bytes())
+}
+
+// 执行模板并返回生成的内容
+func executeTemplate(templatesDir, templateName string, data interface{}) ([]byte, error) {
+	templateContent, err := templates.Load(templatesDir, templateName)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := template.New(templateName).Parse(string(templateContent))
+	if err != nil {
+		return nil, fmt.Errorf("解析模板 %s 失败: %v", templateName, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("执行模板 %s 失败: %v", templateName, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// 查找映射中键对应的值，不存在时添加空映射，空的流式映射（如 paths: {}）改为块格式
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			value := mapping.Content[i+1]
+			if value.Kind != yaml.MappingNode {
+				*value = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			}
+			value.Style = 0
+			return value
+		}
+	}
+	value := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+	return value
+}
+
+// 将 src 中的键值写入 dst，已有的键替换值并保留位置，新的键追加到末尾
+func mergeMapping(dst, src *yaml.Node) {
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		key, value := src.Content[i], src.Content[i+1]
+		replaced := false
+		for j := 0; j+1 < len(dst.Content); j += 2 {
+			if dst.Content[j].Value == key.Value {
+				dst.Content[j+1] = value
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			dst.Content = append(dst.Content, key, value)
+		}
+	}
+}
+
+// 模板中的字段结构以JSON给出，统一改为块格式输出
+func resetStyle(node *yaml.Node) {
+	if node.Kind != yaml.ScalarNode {
+		node.Style = 0
+	} else if node.Style == yaml.DoubleQuotedStyle || node.Style == yaml.SingleQuotedStyle {
+		node.Style = 0
+	}
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
+
+// OpenAPI 中的字段结构
+type openAPISchema struct {
+	Type        string      `json:"type,omitempty"`
+	Format      string      `json:"format,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Nullable    bool        `json:"nullable,omitempty"`
+	MinLength   *int        `json:"minLength,omitempty"`
+	MaxLength   *int        `json:"maxLength,omitempty"`
+	Minimum     *float64    `json:"minimum,omitempty"`
+	Maximum     *float64    `json:"maximum,omitempty"`
+	Default     interface{} `json:"default,omitempty"`
+}
+
+// Go类型对应的 OpenAPI 类型和格式，无法对应的类型返回空，表示任意值
+func openAPIType(goType string) (string, string) {
+	switch goType {
+	case "string":
+		return "string", ""
+	case "bool":
+		return "boolean", ""
+	case "int8", "int16", "int32", "uint8", "uint16", "uint32":
+		return "integer", "int32"
+	case "int", "int64", "uint", "uint64":
+		return "integer", "int64"
+	case "float32":
+		return "number", "float"
+	case "float64":
+		return "number", "double"
+	case "time.Time":
+		return "string", "date-time"
+	case "[]byte":
+		return "string", "byte"
+	}
+	return "", ""
+}
+
+// 转换为JSON，JSON是合法的YAML，可以直接写入模板
+func schemaJSON(schema openAPISchema) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(schema)
+	return strings.TrimSpace(buf.String())
+}
+
+// IDSchema 主键在 OpenAPI 中的结构(JSON)
+func (c ModelConfig) IDSchema() string {
+	goType := c.ID
+	if goType == "" {
+		goType = "uint"
+	}
+	var schema openAPISchema
+	schema.Type, schema.Format = openAPIType(goType)
+	return schemaJSON(schema)
+}
+
+// RequiredJSONNames 必填字段的JSON字段名
+func (c ModelConfig) RequiredJSONNames() []string {
+	var names []string
+	for _, field := range c.Fields {
+		if field.Required {
+			names = append(names, field.JSONName())
+		}
+	}
+	return names
+}
+
+// OpenAPISchema 字段在 OpenAPI 中的结构(JSON)，根据类型、可空、长度、取值范围、默认值和注释生成
+func (f Field) OpenAPISchema() string {
+	baseType := f.BaseType()
+	schema := openAPISchema{Description: f.Comment, Nullable: f.Nullable || strings.HasPrefix(f.Type, "*")}
+	schema.Type, schema.Format = openAPIType(baseType)
+
+	min, hasMin := parseNumber(f.Min)
+	max, hasMax := parseNumber(f.Max)
+	switch schema.Type {
+	case "string":
+		if hasMin && schema.Format == "" {
+			n := int(min)
+			schema.MinLength = &n
+		}
+		if hasMax && schema.Format == "" {
+			n := int(max)
+			schema.MaxLength = &n
+		} else if f.Size > 0 && schema.Format == "" {
+			schema.MaxLength = &f.Size
+		}
+	case "integer", "number":
+		if hasMin {
+			schema.Minimum = &min
+		} else if strings.HasPrefix(baseType, "uint") {
+			zero := 0.0
+			schema.Minimum = &zero
+		}
+		if hasMax {
+			schema.Maximum = &max
+		}
+	}
+
+	if f.Default != "" {
+		switch schema.Type {
+		case "integer", "number":
+			if value, ok := parseNumber(f.Default); ok {
+				schema.Default = value
+			}
+		case "boolean":
+			if value, err := strconv.ParseBool(f.Default); err == nil {
+				schema.Default = value
+			}
+		case "string":
+			if schema.Format == "" {
+				schema.Default = strings.Trim(f.Default, `'"`)
+			}
+		}
+	}
+	return schemaJSON(schema)
+}
+
+func parseNumber(value string) (float64, bool) {
+	if value == "" {
+		return 0, false
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	return number, err == nil
+}
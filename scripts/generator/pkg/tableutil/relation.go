@@ -0,0 +1,322 @@
+package tableutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"strings"
+
+	"github.com/liam/go_web_quick_start/scripts/generator/pkg/fileutil"
+	"gopkg.in/yaml.v3"
+)
+
+// 关联关系类型
+const (
+	BelongsTo  = "belongs_to"
+	HasMany    = "has_many"
+	ManyToMany = "many_to_many"
+)
+
+// 关联关系定义语法，交互输入和模型定义文件中通用：
+//
+//	belongs_to User [name=Author] [foreign_key=AuthorID]
+//	has_many Order [name=Orders] [foreign_key=PostID]
+//	many_to_many Tag [through post_tags]
+//
+// 关联的模型需要在 internal/model 中存在，通常先为关联表生成代码。
+const RelationSyntax = "belongs_to|has_many|many_to_many 关联模型 [name=字段名] [foreign_key=外键字段] [through 中间表]"
+
+// Relation 关联关系定义
+type Relation struct {
+	Type       string `yaml:"type" json:"type"`               // belongs_to、has_many、many_to_many
+	Model      string `yaml:"model" json:"model"`             // 关联的模型名
+	Name       string `yaml:"name" json:"name"`               // 关联字段名，默认为模型名（has_many、many_to_many 为复数）
+	ForeignKey string `yaml:"foreign_key" json:"foreign_key"` // 外键字段名
+	Through    string `yaml:"through" json:"through"`         // many_to_many 的中间表
+}
+
+// ParseRelation 按关联关系定义语法解析一行关联关系
+func ParseRelation(line string) (Relation, error) {
+	tokens, err := splitFieldTokens(line)
+	if err != nil {
+		return Relation{}, err
+	}
+	if len(tokens) < 2 {
+		return Relation{}, fmt.Errorf("关联关系至少需要类型和关联模型: %s", line)
+	}
+
+	relation := Relation{Type: tokens[0], Model: tokens[1]}
+	for i := 2; i < len(tokens); i++ {
+		token := tokens[i]
+		if token == "through" && i+1 < len(tokens) {
+			i++
+			relation.Through = tokens[i]
+			continue
+		}
+
+		key, value, hasValue := strings.Cut(token, "=")
+		if !hasValue || value == "" {
+			return Relation{}, fmt.Errorf("无法识别的关联属性: %s", token)
+		}
+		switch key {
+		case "name":
+			relation.Name = value
+		case "foreign_key":
+			relation.ForeignKey = value
+		case "through":
+			relation.Through = value
+		default:
+			return Relation{}, fmt.Errorf("无法识别的关联属性: %s", token)
+		}
+	}
+	return relation, nil
+}
+
+// UnmarshalYAML 模型定义文件中的关联关系既可以是映射，也可以是一行关联关系定义
+func (r *Relation) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		relation, err := ParseRelation(value.Value)
+		if err != nil {
+			return err
+		}
+		*r = relation
+		return nil
+	}
+
+	type plain Relation
+	return value.Decode((*plain)(r))
+}
+
+// UnmarshalJSON 模型定义文件中的关联关系既可以是对象，也可以是一行关联关系定义
+func (r *Relation) UnmarshalJSON(data []byte) error {
+	var line string
+	if err := json.Unmarshal(data, &line); err == nil {
+		relation, err := ParseRelation(line)
+		if err != nil {
+			return err
+		}
+		*r = relation
+		return nil
+	}
+
+	type plain Relation
+	return json.Unmarshal(data, (*plain)(r))
+}
+
+// 校验关联关系并补全默认值，belongs_to 的外键字段不存在时自动加入字段列表
+func normalizeRelations(config *ModelConfig) error {
+	names := make(map[string]bool)
+	for _, field := range config.Fields {
+		names[field.Name] = true
+	}
+
+	for i := range config.Relations {
+		relation := &config.Relations[i]
+		if relation.Model == "" {
+			return fmt.Errorf("第 %d 个关联关系缺少关联模型", i+1)
+		}
+		relation.Model = exportedName(relation.Model)
+
+		switch relation.Type {
+		case BelongsTo:
+			if relation.Name == "" {
+				relation.Name = relation.Model
+			}
+			if relation.ForeignKey == "" {
+				relation.ForeignKey = relation.Name + "ID"
+			}
+		case HasMany:
+			if relation.Name == "" {
+				relation.Name = relation.Model + "s"
+			}
+			if relation.ForeignKey == "" {
+				relation.ForeignKey = config.ModelName + "ID"
+			}
+		case ManyToMany:
+			if relation.Name == "" {
+				relation.Name = relation.Model + "s"
+			}
+			if relation.Through == "" {
+				relation.Through = config.TableName + "_" + toSnakeCase(relation.Name)
+			}
+		default:
+			return fmt.Errorf("不支持的关联类型 %s（仅支持 %s、%s、%s）", relation.Type, BelongsTo, HasMany, ManyToMany)
+		}
+		relation.Name = exportedName(relation.Name)
+		if relation.ForeignKey != "" {
+			relation.ForeignKey = exportedName(relation.ForeignKey)
+		}
+
+		if names[relation.Name] {
+			return fmt.Errorf("关联字段 %s 与已有字段重名", relation.Name)
+		}
+		names[relation.Name] = true
+
+		// belongs_to 的外键保存在当前表，类型暂用当前模型的主键类型，生成时由 resolveRelations 改为关联模型的主键类型
+		if relation.Type == BelongsTo && !names[relation.ForeignKey] {
+			field := Field{Name: relation.ForeignKey, Type: config.idType(), Index: true, autoForeignKey: true}
+			if err := normalizeField(&field); err != nil {
+				return err
+			}
+			config.Fields = append(config.Fields, field)
+			names[relation.ForeignKey] = true
+		}
+	}
+	return nil
+}
+
+// 按项目 internal/model 中的关联模型校验关联关系：
+// belongs_to 自动添加的外键使用关联模型的主键类型，声明的外键类型必须与之一致；
+// has_many 的外键字段必须已存在于关联模型中，否则gorm无法解析关联
+func resolveRelations(config *ModelConfig, projectRoot string) error {
+	for _, relation := range config.Relations {
+		if relation.Type == ManyToMany {
+			continue
+		}
+
+		target := config.modelInfo()
+		if relation.Model != config.ModelName {
+			info, err := loadModelInfo(projectRoot, relation.Model)
+			if err != nil {
+				return err
+			}
+			if info == nil {
+				return fmt.Errorf("关联模型 %s 不存在于 internal/model 中，请先为其生成代码", relation.Model)
+			}
+			target = info
+		}
+
+		switch relation.Type {
+		case BelongsTo:
+			for i := range config.Fields {
+				field := &config.Fields[i]
+				if field.Name != relation.ForeignKey {
+					continue
+				}
+				if field.autoForeignKey {
+					field.Type = target.idType
+				} else if field.BaseType() != target.idType {
+					return fmt.Errorf("外键 %s 的类型 %s 与 %s 的主键类型 %s 不一致", field.Name, field.Type, relation.Model, target.idType)
+				}
+			}
+		case HasMany:
+			if _, ok := target.fields[relation.ForeignKey]; !ok {
+				return fmt.Errorf("关联模型 %s 中缺少外键字段 %s：请先在 %s 的字段定义中添加 %s，或用 foreign_key= 指定已有字段",
+					relation.Model, relation.ForeignKey, relation.Model, toSnakeCase(relation.ForeignKey))
+			}
+		}
+	}
+	return nil
+}
+
+// 已生成模型的主键类型和字段
+type modelInfo struct {
+	idType string
+	fields map[string]string // 字段名 -> Go类型
+}
+
+// 当前模型的主键类型和字段，用于关联自身的关联关系
+func (c ModelConfig) modelInfo() *modelInfo {
+	info := &modelInfo{idType: c.idType(), fields: make(map[string]string)}
+	for _, field := range c.Fields {
+		info.fields[field.Name] = field.Type
+	}
+	return info
+}
+
+// 主键的Go类型，未指定时为 uint
+func (c ModelConfig) idType() string {
+	if c.ID == "" {
+		return "uint"
+	}
+	return c.ID
+}
+
+// 在 internal/model 中查找模型的结构体定义，模型不存在时返回 nil
+func loadModelInfo(projectRoot, modelName string) (*modelInfo, error) {
+	paths, err := filepath.Glob(filepath.Join(projectRoot, "internal", "model", "*.go"))
+	if err != nil {
+		return nil, fmt.Errorf("查找模型文件失败: %v", err)
+	}
+
+	for _, path := range paths {
+		content, _, err := fileutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("读取文件 %s 失败: %v", path, err)
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), path, content, 0)
+		if err != nil {
+			return nil, fmt.Errorf("解析 %s 失败: %v", path, err)
+		}
+		structType := findStruct(file, modelName)
+		if structType == nil {
+			continue
+		}
+
+		info := &modelInfo{fields: make(map[string]string)}
+		for _, field := range structType.Fields.List {
+			for _, name := range field.Names {
+				info.fields[name.Name] = types.ExprString(field.Type)
+			}
+		}
+		info.idType = info.fields["ID"]
+		if info.idType == "" {
+			return nil, fmt.Errorf("%s 中的 %s 缺少 ID 字段", path, modelName)
+		}
+		return info, nil
+	}
+	return nil, nil
+}
+
+// 查找顶层结构体类型
+func findStruct(file *ast.File, name string) *ast.StructType {
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			if structType, ok := typeSpec.Type.(*ast.StructType); ok && typeSpec.Name.Name == name {
+				return structType
+			}
+		}
+	}
+	return nil
+}
+
+// IsBelongsTo 是否为 belongs_to 关联
+func (r Relation) IsBelongsTo() bool {
+	return r.Type == BelongsTo
+}
+
+// FieldType 关联字段的Go类型
+func (r Relation) FieldType() string {
+	if r.Type == BelongsTo {
+		return "*" + r.Model
+	}
+	return "[]" + r.Model
+}
+
+// StructTag 关联字段的结构体标签
+func (r Relation) StructTag() string {
+	gormTag := "foreignKey:" + r.ForeignKey
+	if r.Type == ManyToMany {
+		gormTag = "many2many:" + r.Through
+	}
+	return fmt.Sprintf("`gorm:\"%s\" json:\"%s,omitempty\"`", gormTag, r.Path())
+}
+
+// Path 嵌套路由的路径，如 /posts/:id/tags 中的 tags
+func (r Relation) Path() string {
+	return toSnakeCase(r.Name)
+}
+
+// ForeignKeyColumn 外键列名
+func (r Relation) ForeignKeyColumn() string {
+	return toSnakeCase(r.ForeignKey)
+}
@@ -0,0 +1,217 @@
+package tableutil
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseRelation(t *testing.T) {
+	tests := []struct {
+		line string
+		want Relation
+	}{
+		{
+			line: "belongs_to User",
+			want: Relation{Type: BelongsTo, Model: "User"},
+		},
+		{
+			line: "belongs_to User name=Author foreign_key=AuthorID",
+			want: Relation{Type: BelongsTo, Model: "User", Name: "Author", ForeignKey: "AuthorID"},
+		},
+		{
+			line: "has_many Comment foreign_key=PostID",
+			want: Relation{Type: HasMany, Model: "Comment", ForeignKey: "PostID"},
+		},
+		{
+			line: "many_to_many Tag through post_tags",
+			want: Relation{Type: ManyToMany, Model: "Tag", Through: "post_tags"},
+		},
+		{
+			line: "many_to_many Tag name=Labels through=post_labels",
+			want: Relation{Type: ManyToMany, Model: "Tag", Name: "Labels", Through: "post_labels"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got, err := ParseRelation(tt.line)
+			if err != nil {
+				t.Fatalf("ParseRelation(%q) 返回错误: %v", tt.line, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseRelation(%q) = %+v, 期望 %+v", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRelationErrors(t *testing.T) {
+	tests := []struct {
+		line string
+		want string // 错误信息中应包含的内容
+	}{
+		{line: "belongs_to", want: "至少需要类型和关联模型"},
+		{line: "", want: "至少需要类型和关联模型"},
+		{line: "belongs_to User fk=UserID", want: "无法识别的关联属性: fk=UserID"},
+		{line: "belongs_to User name", want: "无法识别的关联属性: name"},
+		{line: "has_many Comment foreign_key=", want: "无法识别的关联属性: foreign_key="},
+		{line: "many_to_many Tag through", want: "无法识别的关联属性: through"},
+		{line: "belongs_to User name='Author", want: "引号未闭合"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			_, err := ParseRelation(tt.line)
+			if err == nil {
+				t.Fatalf("ParseRelation(%q) 应返回错误", tt.line)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseRelation(%q) 的错误 %q 中应包含 %q", tt.line, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeRelations(t *testing.T) {
+	tests := []struct {
+		name     string
+		relation Relation
+		want     Relation
+		fkAdded  bool // 是否自动添加外键字段
+	}{
+		{
+			name:     "belongs_to 默认字段名和外键",
+			relation: Relation{Type: BelongsTo, Model: "user"},
+			want:     Relation{Type: BelongsTo, Model: "User", Name: "User", ForeignKey: "UserID"},
+			fkAdded:  true,
+		},
+		{
+			name:     "has_many 默认外键为当前模型名加 ID",
+			relation: Relation{Type: HasMany, Model: "Comment"},
+			want:     Relation{Type: HasMany, Model: "Comment", Name: "Comments", ForeignKey: "PostID"},
+		},
+		{
+			name:     "many_to_many 默认中间表",
+			relation: Relation{Type: ManyToMany, Model: "Tag"},
+			want:     Relation{Type: ManyToMany, Model: "Tag", Name: "Tags", Through: "posts_tags"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &ModelConfig{TableName: "posts", ModelName: "Post", Relations: []Relation{tt.relation}}
+			if err := normalizeRelations(config); err != nil {
+				t.Fatalf("normalizeRelations 返回错误: %v", err)
+			}
+			if config.Relations[0] != tt.want {
+				t.Errorf("关联关系为 %+v, 期望 %+v", config.Relations[0], tt.want)
+			}
+			if added := len(config.Fields) == 1 && config.Fields[0].Name == tt.want.ForeignKey; added != tt.fkAdded {
+				t.Errorf("外键字段 %+v, 期望自动添加: %v", config.Fields, tt.fkAdded)
+			}
+		})
+	}
+}
+
+func TestNormalizeRelationsErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields []Field
+		line   string
+		want   string
+	}{
+		{name: "不支持的类型", line: "has_one User", want: "不支持的关联类型 has_one"},
+		{name: "与字段重名", fields: []Field{{Name: "Author", Type: "string"}}, line: "belongs_to User name=Author", want: "与已有字段重名"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			relation, err := ParseRelation(tt.line)
+			if err != nil {
+				t.Fatalf("ParseRelation(%q) 返回错误: %v", tt.line, err)
+			}
+			config := &ModelConfig{TableName: "posts", ModelName: "Post", Fields: tt.fields, Relations: []Relation{relation}}
+			err = normalizeRelations(config)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("normalizeRelations 的错误为 %v, 期望包含 %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// 关联测试使用的已生成模型
+const relationTestModels = `package model
+
+type Account struct {
+	ID   string
+	Name string
+}
+
+type Comment struct {
+	ID     uint
+	PostID uint
+	Body   string
+}
+`
+
+func TestResolveRelations(t *testing.T) {
+	projectRoot := t.TempDir()
+	modelDir := filepath.Join(projectRoot, "internal", "model")
+	if err := os.MkdirAll(modelDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(modelDir, "models.go"), []byte(relationTestModels), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		fields  []Field
+		line    string
+		fkType  string // 外键字段的类型，为空时不检查
+		wantErr string // 为空时应成功
+	}{
+		{name: "belongs_to 外键使用关联模型的主键类型", line: "belongs_to Account", fkType: "string"},
+		{name: "声明的外键类型一致", fields: []Field{{Name: "AccountID", Type: "string"}}, line: "belongs_to Account", fkType: "string"},
+		{name: "声明的外键类型不一致", fields: []Field{{Name: "AccountID", Type: "uint"}}, line: "belongs_to Account", wantErr: "与 Account 的主键类型 string 不一致"},
+		{name: "关联自身", line: "belongs_to Post name=Parent", fkType: "uint"},
+		{name: "has_many 外键存在", line: "has_many Comment"},
+		{name: "has_many 外键不存在", line: "has_many Comment foreign_key=ArticleID", wantErr: "Comment 中缺少外键字段 ArticleID"},
+		{name: "关联模型不存在", line: "belongs_to Author", wantErr: "关联模型 Author 不存在"},
+		{name: "many_to_many 不检查", line: "many_to_many Tag"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			relation, err := ParseRelation(tt.line)
+			if err != nil {
+				t.Fatalf("ParseRelation(%q) 返回错误: %v", tt.line, err)
+			}
+			config := &ModelConfig{TableName: "posts", ModelName: "Post", Fields: tt.fields, Relations: []Relation{relation}}
+			if err := normalizeRelations(config); err != nil {
+				t.Fatalf("normalizeRelations 返回错误: %v", err)
+			}
+
+			err = resolveRelations(config, projectRoot)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("resolveRelations 的错误为 %v, 期望包含 %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveRelations 返回错误: %v", err)
+			}
+			if tt.fkType == "" {
+				return
+			}
+			for _, field := range config.Fields {
+				if field.Name == config.Relations[0].ForeignKey && field.Type != tt.fkType {
+					t.Errorf("外键 %s 的类型为 %s, 期望 %s", field.Name, field.Type, tt.fkType)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package tableutil
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"text/template"
+
+	"github.com/liam/go_web_quick_start/scripts/generator/pkg/fileutil"
+	"github.com/liam/go_web_quick_start/scripts/generator/templates"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// 路由注册函数、API版本分组和管理接口分组的变量名，与router.tmpl保持一致
+const (
+	registerRoutesFunc = "RegisterRoutes"
+	routerGroupVar     = "v1"
+	adminGroupVar      = "admin"
+)
+
+// ErrRouteRegistered 模块的Handler已注册到路由
+var ErrRouteRegistered = errors.New("已注册到 " + registerRoutesFunc)
+
+// UpdateRouter 将新模块的Handler注册到 api.RegisterRoutes
+// 插入router_register.tmpl生成的代码：构建Service并调用 Register(v1)、RegisterAdmin(admin)
+func UpdateRouter(filePath, templatesDir string, config ModelConfig) error {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("读取文件失败: %v", err)
+	}
+
+	templateContent, err := templates.Load(templatesDir, "router_register.tmpl")
+	if err != nil {
+		return err
+	}
+	tmpl, err := template.New("router_register.tmpl").Parse(string(templateContent))
+	if err != nil {
+		return fmt.Errorf("解析路由模板失败: %v", err)
+	}
+	var snippet bytes.Buffer
+	if err := tmpl.Execute(&snippet, config); err != nil {
+		return fmt.Errorf("生成路由注册代码失败: %v", err)
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filePath, content, parser.ParseComments)
+	if err != nil {
+		return fmt.Errorf("解析 %s 失败: %v", filePath, err)
+	}
+
+	fn := findFunc(file, registerRoutesFunc)
+	if fn == nil || fn.Body == nil {
+		return fmt.Errorf("%s 中未找到 %s 函数", filePath, registerRoutesFunc)
+	}
+
+	handlerFunc := "New" + config.ModelName + "Handler"
+	if callsFunc(fn.Body, handlerFunc) {
+		return fmt.Errorf("%s %w", handlerFunc, ErrRouteRegistered)
+	}
+
+	block, err := routeBlock(fn.Body)
+	if err != nil {
+		return fmt.Errorf("%s: %v", filePath, err)
+	}
+
+	// 插入到最后一个 Xxx.Register(v1) 或 Xxx.RegisterAdmin(admin) 之后，没有时插入到分组代码块末尾
+	offset := fset.Position(block.Rbrace).Offset
+	for _, stmt := range block.List {
+		if isRegisterCall(stmt) {
+			offset = lineEnd(content, fset.Position(stmt.End()).Offset) + 1
+		}
+	}
+	text := "\n" + snippet.String()
+	if offset == fset.Position(block.Rbrace).Offset {
+		text += "\n"
+	}
+	updated := applyEdits(content, []textEdit{{offset: offset, text: text}})
+
+	// 确保导入了dao包和service包
+	file, err = parser.ParseFile(fset, filePath, updated, parser.ParseComments)
+	if err != nil {
+		return fmt.Errorf("合并后的 %s 无效: %v", filePath, err)
+	}
+	astutil.AddImport(fset, file, config.ProjectImport+"/internal/dao")
+	astutil.AddImport(fset, file, config.ProjectImport+"/internal/service")
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return fmt.Errorf("格式化 %s 失败: %v", filePath, err)
+	}
+	return fileutil.WriteFile(filePath, buf.Bytes())
+}
+
+// 查找顶层函数
+func findFunc(file *ast.File, name string) *ast.FuncDecl {
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == name {
+			return fn
+		}
+	}
+	return nil
+}
+
+// 代码中是否调用了指定函数
+func callsFunc(node ast.Node, name string) bool {
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			if ident, ok := call.Fun.(*ast.Ident); ok && ident.Name == name {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// 查找 v1 := r.Group(...) 之后的分组代码块，没有代码块时使用函数体
+func routeBlock(body *ast.BlockStmt) (*ast.BlockStmt, error) {
+	for i, stmt := range body.List {
+		assign, ok := stmt.(*ast.AssignStmt)
+		if !ok || len(assign.Lhs) != 1 {
+			continue
+		}
+		if ident, ok := assign.Lhs[0].(*ast.Ident); !ok || ident.Name != routerGroupVar {
+			continue
+		}
+		if i+1 < len(body.List) {
+			if block, ok := body.List[i+1].(*ast.BlockStmt); ok {
+				return block, nil
+			}
+		}
+		return body, nil
+	}
+	return nil, fmt.Errorf("%s 中未找到路由分组 %s", registerRoutesFunc, routerGroupVar)
+}
+
+// 是否为 Xxx.Register(v1) 或 Xxx.RegisterAdmin(admin) 形式的语句
+func isRegisterCall(stmt ast.Stmt) bool {
+	exprStmt, ok := stmt.(*ast.ExprStmt)
+	if !ok {
+		return false
+	}
+	call, ok := exprStmt.X.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	arg, isIdent := call.Args[0].(*ast.Ident)
+	if !ok || !isIdent {
+		return false
+	}
+	return sel.Sel.Name == "Register" && arg.Name == routerGroupVar ||
+		sel.Sel.Name == "RegisterAdmin" && arg.Name == adminGroupVar
+}
+
+// 偏移所在行的行尾偏移
+func lineEnd(content []byte, offset int) int {
+	for offset < len(content) && content[offset] != '\n' {
+		offset++
+	}
+	return offset
+}
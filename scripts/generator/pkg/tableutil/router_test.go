@@ -0,0 +1,151 @@
+package tableutil
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// 新项目中的 router.go
+const routerFixture = `package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"example.com/app/internal/dao"
+	"example.com/app/internal/middleware"
+	"example.com/app/internal/response"
+	"example.com/app/internal/service"
+)
+
+// RegisterRoutes 注册API路由
+func RegisterRoutes(r *gin.Engine, db *gorm.DB) {
+	// API版本分组
+	v1 := r.Group("/api/v1")
+	{
+		// 管理接口，需要管理员权限
+		admin := v1.Group("/admin", middleware.AdminOnly())
+
+		// 注册用户API
+		userService := service.NewUserService(dao.NewUserDAO(db))
+		userHandler := NewUserHandler(userService)
+		userHandler.Register(v1)
+		userHandler.RegisterAdmin(admin)
+
+		// 其他API路由
+		v1.GET("/ping", func(c *gin.Context) {
+			response.OK(c, gin.H{"message": "pong"})
+		})
+	}
+}
+`
+
+// 没有分组代码块、也没有导入dao和service的 router.go
+const routerFixtureNoBlock = `package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// RegisterRoutes 注册API路由
+func RegisterRoutes(r *gin.Engine, db *gorm.DB) {
+	v1 := r.Group("/api/v1")
+	admin := v1.Group("/admin")
+	_ = admin
+}
+`
+
+func TestUpdateRouter(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		after   string // 注册代码应位于该行之后
+		before  string // 注册代码应位于该行之前
+		wantErr string // 为空时应成功
+	}{
+		{
+			name:    "插入到已有模块之后",
+			content: routerFixture,
+			after:   "userHandler.RegisterAdmin(admin)",
+			before:  "// 其他API路由",
+		},
+		{
+			name:    "没有分组代码块时插入到函数末尾",
+			content: routerFixtureNoBlock,
+			after:   "_ = admin",
+			before:  "}",
+		},
+		{
+			name:    "缺少RegisterRoutes",
+			content: "package api\n",
+			wantErr: "未找到 RegisterRoutes 函数",
+		},
+		{
+			name:    "缺少路由分组",
+			content: "package api\n\nfunc RegisterRoutes() {\n}\n",
+			wantErr: "未找到路由分组 v1",
+		},
+	}
+
+	config := ModelConfig{ProjectImport: "example.com/app", TableName: "posts", ModelName: "Post", ModuleName: "post"}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "router.go")
+			if err := os.WriteFile(filePath, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			err := UpdateRouter(filePath, "", config)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("UpdateRouter 的错误为 %v, 期望包含 %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpdateRouter 返回错误: %v", err)
+			}
+
+			content, err := os.ReadFile(filePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			updated := string(content)
+			for _, want := range []string{
+				`"example.com/app/internal/dao"`,
+				`"example.com/app/internal/service"`,
+				"postService := service.NewPostService(db, dao.NewPostDAO(db))",
+				"postHandler.RegisterAdmin(admin)",
+			} {
+				if !strings.Contains(updated, want) {
+					t.Errorf("更新后的文件中缺少 %q:\n%s", want, updated)
+				}
+			}
+			block := strings.Index(updated, "// 注册postsAPI")
+			if block < 0 {
+				t.Fatalf("更新后的文件中缺少注册代码:\n%s", updated)
+			}
+			if after := strings.Index(updated, tt.after); after < 0 || after > block {
+				t.Errorf("注册代码应位于 %q 之后:\n%s", tt.after, updated)
+			}
+			if before := strings.Index(updated[block:], tt.before); before < 0 {
+				t.Errorf("注册代码应位于 %q 之前:\n%s", tt.before, updated)
+			}
+
+			// 重复注册时返回 ErrRouteRegistered，不修改文件
+			if err := UpdateRouter(filePath, "", config); !errors.Is(err, ErrRouteRegistered) {
+				t.Fatalf("重复注册应返回 ErrRouteRegistered, 实际为 %v", err)
+			}
+			again, err := os.ReadFile(filePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(again) != updated {
+				t.Errorf("重复注册不应修改文件:\n%s", again)
+			}
+		})
+	}
+}
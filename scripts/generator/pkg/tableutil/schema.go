@@ -0,0 +1,85 @@
+package tableutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadModelConfig 从YAML/JSON模型定义文件加载模型配置
+//
+// 文件格式示例(YAML)：
+//
+//	project_import: github.com/yourusername/myproject
+//	db_type: mysql
+//	table_name: users
+//	fields:
+//	  - name string required size=100 comment=名称
+//	  - name: Age
+//	    type: int
+//	versioned: true
+//	relations:
+//	  - belongs_to Department
+//	  - many_to_many Role through user_roles
+//
+// model_name、module_name、id_type 未填写时按交互模式相同的规则推导。
+func LoadModelConfig(path string) (*ModelConfig, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取模型定义文件失败: %v", err)
+	}
+
+	var config ModelConfig
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(content, &config)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &config)
+	default:
+		return nil, fmt.Errorf("不支持的模型定义文件格式: %s（仅支持 .yaml、.yml、.json）", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("解析模型定义文件 %s 失败: %v", path, err)
+	}
+
+	if err := normalizeModelConfig(&config); err != nil {
+		return nil, fmt.Errorf("模型定义文件 %s 无效: %v", path, err)
+	}
+	return &config, nil
+}
+
+// 校验模型配置并补全默认值
+func normalizeModelConfig(config *ModelConfig) error {
+	if config.TableName == "" {
+		return fmt.Errorf("缺少 table_name")
+	}
+	if config.ProjectImport == "" {
+		config.ProjectImport = "github.com/yourusername/myproject"
+	}
+	if config.DBType == "" {
+		config.DBType = "mysql"
+	}
+	if config.ModelName == "" {
+		config.ModelName = defaultModelName(config.TableName)
+	}
+	if config.ModuleName == "" {
+		config.ModuleName = defaultModuleName(config.ModelName)
+	}
+	if config.ID == "" {
+		config.ID = GetIDType(config.DBType)
+	}
+
+	for i := range config.Fields {
+		if err := normalizeField(&config.Fields[i]); err != nil {
+			return fmt.Errorf("第 %d 个字段无效: %v", i+1, err)
+		}
+		if config.Versioned && config.Fields[i].Column == "version" {
+			return fmt.Errorf("启用乐观锁时会自动添加 version 列，不能再定义同名字段")
+		}
+	}
+	return normalizeRelations(config)
+}
@@ -2,30 +2,99 @@ package tableutil
 
 import (
 	"bufio"
+	"bytes"
+	"errors"
+	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"text/template"
+
+	"github.com/liam/go_web_quick_start/scripts/generator/pkg/fileutil"
+	"github.com/liam/go_web_quick_start/scripts/generator/templates"
 )
 
 // ModelConfig 存储用户输入的模型配置信息
 type ModelConfig struct {
-	ModuleName    string
-	TableName     string
-	ModelName     string
-	Fields        []Field
-	ProjectImport string
-	ID            string // ID类型
-	DBType        string // 数据库类型
+	ModuleName    string       `yaml:"module_name" json:"module_name"`
+	TableName     string       `yaml:"table_name" json:"table_name"`
+	ModelName     string       `yaml:"model_name" json:"model_name"`
+	Fields        []Field      `yaml:"fields" json:"fields"`
+	Relations     []Relation   `yaml:"relations" json:"relations"` // 关联关系
+	Versioned     bool         `yaml:"versioned" json:"versioned"` // 启用乐观锁，添加 version 列
+	ProjectImport string       `yaml:"project_import" json:"project_import"`
+	ID            string       `yaml:"id_type" json:"id_type"` // ID类型
+	DBType        string       `yaml:"db_type" json:"db_type"` // 数据库类型
+	Builtin       bool         `yaml:"-" json:"-"`             // 项目自带的用户模块，由user_*.tmpl生成
+	ProtoLayout   *ProtoLayout `yaml:"-" json:"-"`             // 已有proto文件中的字段编号，见 GenerateGRPC
+}
+
+// NewServiceExpr 注册代码中构建 service.XxxService 的表达式，使用变量 db
+// 自带的用户模块的Service不接收 db
+func (c ModelConfig) NewServiceExpr() string {
+	if c.Builtin {
+		return fmt.Sprintf("service.New%sService(dao.New%sDAO(db))", c.ModelName, c.ModelName)
+	}
+	return fmt.Sprintf("service.New%sService(db, dao.New%sDAO(db))", c.ModelName, c.ModelName)
 }
 
-// Field 字段定义
+// IDQueryType 主键在生成项目 dao.Columns 中的值类型
+func (c ModelConfig) IDQueryType() string {
+	if c.ID == "" {
+		return queryType("uint")
+	}
+	return queryType(c.ID)
+}
+
+// Field 字段定义，语法见 FieldSyntax
 type Field struct {
-	Name    string
-	Type    string
-	Tag     string
-	Comment string
+	Name       string `yaml:"name" json:"name"`
+	Type       string `yaml:"type" json:"type"`
+	Tag        string `yaml:"tag" json:"tag"` // 自定义结构体标签，填写后不再根据属性生成
+	Comment    string `yaml:"comment" json:"comment"`
+	Column     string `yaml:"column" json:"column"`           // 列名
+	ColumnType string `yaml:"column_type" json:"column_type"` // 数据库列类型
+	Required   bool   `yaml:"required" json:"required"`       // 必填
+	NotNull    bool   `yaml:"not_null" json:"not_null"`       // 列不可为空
+	Nullable   bool   `yaml:"nullable" json:"nullable"`       // 可空
+	Unique     bool   `yaml:"unique" json:"unique"`           // 唯一索引
+	Index      bool   `yaml:"index" json:"index"`             // 普通索引
+	Size       int    `yaml:"size" json:"size"`               // 最大长度
+	Min        string `yaml:"min" json:"min"`                 // 最小值
+	Max        string `yaml:"max" json:"max"`                 // 最大值
+	Default    string `yaml:"default" json:"default"`         // 默认值
+
+	autoForeignKey bool // belongs_to 关联自动添加的外键，类型取关联模型的主键类型
+}
+
+// Options 表代码生成器的运行选项
+type Options struct {
+	SchemaFile  string // 模型定义文件(YAML/JSON)，为空时交互式输入
+	ProjectRoot string // 项目根目录，为空时交互式输入
+	FromDB      bool   // 从已有数据库表读取字段定义
+	Table       string // 从数据库读取的表名，为空时交互式输入
+	ConfigFile  string // 项目配置文件，默认为 <项目根目录>/config/config.yaml
+	Templates   string // 自定义模板目录，其中的同名模板优先于内置模板
+	DryRun      bool   // 只列出将创建或修改的文件，不写入
+	Diff        bool   // 输出与磁盘上已有文件的统一差异，不写入
+}
+
+// Run 解析命令行参数并运行表代码生成器
+func Run(args []string) {
+	var opts Options
+	flags := flag.NewFlagSet("create-table", flag.ExitOnError)
+	flags.StringVar(&opts.SchemaFile, "schema", "", "模型定义文件(YAML/JSON)，指定后不再交互式输入")
+	flags.StringVar(&opts.ProjectRoot, "root", "", "项目根目录（使用 --schema 时默认为当前目录）")
+	flags.BoolVar(&opts.FromDB, "from-db", false, "连接项目数据库，从已有表读取字段定义")
+	flags.StringVar(&opts.Table, "table", "", "配合 --from-db 使用的表名")
+	flags.StringVar(&opts.ConfigFile, "config", "", "项目配置文件（默认 <root>/config/config.yaml）")
+	flags.StringVar(&opts.Templates, "templates", "", "自定义模板目录，其中的同名模板优先于内置模板")
+	flags.BoolVar(&opts.DryRun, "dry-run", false, "只列出将创建或修改的文件，不写入")
+	flags.BoolVar(&opts.Diff, "diff", false, "输出与磁盘上已有文件的统一差异，不写入")
+	flags.Parse(args)
+
+	GenerateTable(opts)
 }
 
 // 获取用户输入
@@ -81,163 +150,75 @@ func GetIDType(dbType string) string {
 }
 
 // 生成文件从模板
-func GenerateFileFromTemplate(filePath, templatePath string, data interface{}) error {
-	// 确保目录存在
-	dir := filepath.Dir(filePath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("创建目录失败: %v", err)
-	}
-
+// templatesDir 为自定义模板目录，为空时使用内置模板
+func GenerateFileFromTemplate(filePath, templatesDir, templateName string, data interface{}) error {
 	// 读取模板文件
-	templateContent, err := os.ReadFile(templatePath)
-	if err != nil {
-		return fmt.Errorf("读取模板文件失败: %v", err)
-	}
-
-	// 创建文件
-	file, err := os.Create(filePath)
+	templateContent, err := templates.Load(templatesDir, templateName)
 	if err != nil {
-		return fmt.Errorf("创建文件失败: %v", err)
+		return err
 	}
-	defer file.Close()
 
 	// 解析模板
-	tmpl, err := template.New(filepath.Base(templatePath)).Parse(string(templateContent))
+	tmpl, err := template.New(templateName).Parse(string(templateContent))
 	if err != nil {
 		return fmt.Errorf("解析模板失败: %v", err)
 	}
 
 	// 执行模板
-	if err := tmpl.Execute(file, data); err != nil {
+	var content bytes.Buffer
+	if err := tmpl.Execute(&content, data); err != nil {
 		return fmt.Errorf("执行模板失败: %v", err)
 	}
 
-	return nil
+	// 写入文件
+	return fileutil.WriteFile(filePath, content.Bytes())
 }
 
-// 更新wire provider文件
-func UpdateWireProvider(filePath string, modelName, tableName, moduleName string) error {
-	// 读取现有内容
-	content, err := os.ReadFile(filePath)
-	if err != nil {
-		return fmt.Errorf("读取文件失败: %v", err)
-	}
-
-	// 读取wire_provider.tmpl模板
-	templatePath := filepath.Join("scripts", "generator", "templates", "wire_provider.tmpl")
-	templateContent, err := os.ReadFile(templatePath)
-	if err != nil {
-		return fmt.Errorf("读取模板文件失败: %v", err)
-	}
+// 表结构生成器主函数
+func GenerateTable(opts Options) {
+	fileutil.SetMode(opts.DryRun, opts.Diff)
 
-	// 找到合适的位置插入新内容（在ProviderSet定义之前）
-	lines := strings.Split(string(content), "\n")
-	var newContent strings.Builder
+	var config ModelConfig
+	projectRoot := opts.ProjectRoot
 
-	providerSetIndex := -1
-	for i, line := range lines {
-		if strings.Contains(line, "var ProviderSet = wire.NewSet(") {
-			providerSetIndex = i
-			break
+	if opts.SchemaFile != "" {
+		// 从模型定义文件加载配置，不再交互
+		loaded, err := LoadModelConfig(opts.SchemaFile)
+		if err != nil {
+			fmt.Printf("加载模型定义文件失败: %v\n", err)
+			os.Exit(1)
 		}
-	}
-
-	if providerSetIndex == -1 {
-		return fmt.Errorf("未找到ProviderSet定义")
-	}
-
-	// 解析模板
-	tmpl, err := template.New("wire").Parse(string(templateContent))
-	if err != nil {
-		return fmt.Errorf("解析Wire模板失败: %v", err)
-	}
-
-	// 准备数据
-	data := struct {
-		ModelName  string
-		TableName  string
-		ModuleName string
-	}{
-		ModelName:  modelName,
-		TableName:  tableName,
-		ModuleName: moduleName,
-	}
-
-	// 执行模板
-	var wireContent strings.Builder
-	err = tmpl.Execute(&wireContent, data)
-	if err != nil {
-		return fmt.Errorf("生成Wire内容失败: %v", err)
-	}
-
-	// 添加新的依赖注入集合
-	for i, line := range lines {
-		newContent.WriteString(line)
-		newContent.WriteString("\n")
-
-		if i == providerSetIndex-1 {
-			newContent.WriteString(wireContent.String())
-			newContent.WriteString("\n")
+		config = *loaded
+		if projectRoot == "" {
+			projectRoot = "."
 		}
-	}
-
-	// 写回文件
-	return os.WriteFile(filePath, []byte(newContent.String()), 0644)
-}
-
-// 更新ProviderSet定义
-func UpdateProviderSet(filePath string, newSet string) error {
-	// 读取现有内容
-	content, err := os.ReadFile(filePath)
-	if err != nil {
-		return fmt.Errorf("读取文件失败: %v", err)
-	}
-
-	// 找到ProviderSet定义
-	lines := strings.Split(string(content), "\n")
-	var newContent strings.Builder
-
-	for i, line := range lines {
-		if strings.Contains(line, "var ProviderSet = wire.NewSet(") {
-			// 找到下一个")"
-			closingIndex := -1
-			for j := i + 1; j < len(lines); j++ {
-				if strings.Contains(lines[j], ")") {
-					closingIndex = j
-					break
-				}
-			}
-
-			if closingIndex != -1 {
-				// 插入新的Set
-				for k := 0; k <= i; k++ {
-					newContent.WriteString(lines[k])
-					newContent.WriteString("\n")
-				}
-
-				// 检查是否已经有其他Set
-				if !strings.Contains(lines[i+1], "//") {
-					newContent.WriteString("\t" + newSet + ",\n")
-				} else {
-					lines[closingIndex-1] = "\t" + newSet + ","
-				}
-
-				for k := i + 1; k < len(lines); k++ {
-					newContent.WriteString(lines[k])
-					newContent.WriteString("\n")
-				}
-
-				// 写回文件
-				return os.WriteFile(filePath, []byte(newContent.String()), 0644)
-			}
+	} else if opts.FromDB {
+		// 从已有数据库表读取字段定义
+		if projectRoot == "" {
+			projectRoot = "."
+		}
+		loaded, err := readModelConfigFromDB(opts, projectRoot)
+		if err != nil {
+			fmt.Printf("读取数据库表结构失败: %v\n", err)
+			os.Exit(1)
+		}
+		config = *loaded
+	} else {
+		config = readModelConfig()
+		if projectRoot == "" {
+			// 确认项目根目录
+			projectRoot = GetUserInput("项目根目录", ".")
 		}
 	}
 
-	return fmt.Errorf("未找到合适的位置插入新Set")
+	if err := generateCode(config, projectRoot, opts.Templates); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
 }
 
-// 表结构生成器主函数
-func GenerateTable() {
+// 交互式读取模型配置
+func readModelConfig() ModelConfig {
 	fmt.Println("=== 数据表代码生成器 ===")
 	fmt.Println("请输入以下信息来生成代码：")
 
@@ -251,8 +232,9 @@ func GenerateTable() {
 	tableName := GetUserInput("表名", "users")
 
 	// 获取字段信息
-	fmt.Println("\n请输入字段信息（每行一个字段，格式：字段名 类型 标签 注释，输入空行结束）：")
-	fmt.Println("例如：name string `gorm:\"type:varchar(100)\" json:\"name\"` 名称")
+	fmt.Println("\n请输入字段信息（每行一个字段，输入空行结束），格式：")
+	fmt.Println("  " + FieldSyntax)
+	fmt.Println("例如：name string required unique size=100 comment=名称")
 
 	var fields []Field
 	scanner := bufio.NewScanner(os.Stdin)
@@ -264,33 +246,26 @@ func GenerateTable() {
 			break
 		}
 
-		parts := strings.SplitN(line, " ", 4)
-		field := Field{
-			Name: parts[0],
-			Type: parts[1],
+		field, err := ParseField(line)
+		if err == nil {
+			err = normalizeField(&field)
 		}
-
-		if len(parts) > 2 {
-			field.Tag = parts[2]
-		}
-
-		if len(parts) > 3 {
-			field.Comment = parts[3]
+		if err != nil {
+			fmt.Printf("字段定义无效: %v，请重新输入\n", err)
+			continue
 		}
 
 		fields = append(fields, field)
 	}
 
+	// 是否启用乐观锁
+	versioned := GetBoolInput("是否启用乐观锁（添加 version 列）", false)
+
 	// 获取模型名称（首字母大写）
-	defaultModelName := strings.ToUpper(tableName[:1]) + tableName[1:]
-	if strings.HasSuffix(defaultModelName, "s") {
-		defaultModelName = defaultModelName[:len(defaultModelName)-1] // 去掉复数形式
-	}
-	modelName := GetUserInput("模型名称", defaultModelName)
+	modelName := GetUserInput("模型名称", defaultModelName(tableName))
 
 	// 获取模块名（首字母小写）
-	defaultModuleName := strings.ToLower(modelName[:1]) + modelName[1:]
-	moduleName := GetUserInput("模块名称", defaultModuleName)
+	moduleName := GetUserInput("模块名称", defaultModuleName(modelName))
 
 	// 获取ID类型
 	idType := GetIDType(dbType)
@@ -301,67 +276,219 @@ func GenerateTable() {
 		TableName:     tableName,
 		ModelName:     modelName,
 		Fields:        fields,
+		Versioned:     versioned,
 		ProjectImport: projectImport,
 		ID:            idType,
 		DBType:        dbType,
 	}
 
-	// 确认项目根目录
-	projectRoot := GetUserInput("项目根目录", ".")
+	// 获取关联关系
+	fmt.Println("\n请输入关联关系（每行一个，输入空行结束），格式：")
+	fmt.Println("  " + RelationSyntax)
+	fmt.Println("例如：belongs_to User 或 many_to_many Tag through post_tags")
+	for {
+		fmt.Print("> ")
+		scanner.Scan()
+		line := scanner.Text()
+		if line == "" {
+			break
+		}
+
+		relation, err := ParseRelation(line)
+		if err == nil {
+			candidate := config
+			candidate.Fields = append([]Field(nil), config.Fields...)
+			candidate.Relations = append(append([]Relation(nil), config.Relations...), relation)
+			if err = normalizeRelations(&candidate); err == nil {
+				config = candidate
+				continue
+			}
+		}
+		fmt.Printf("关联关系无效: %v，请重新输入\n", err)
+	}
+	return config
+}
+
+// 连接项目数据库，根据已有表结构生成模型配置
+func readModelConfigFromDB(opts Options, projectRoot string) (*ModelConfig, error) {
+	configFile := opts.ConfigFile
+	if configFile == "" {
+		configFile = filepath.Join(projectRoot, "config", "config.yaml")
+	}
+	dbConfig, err := LoadDatabaseConfig(configFile)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := OpenDatabase(dbConfig)
+	if err != nil {
+		return nil, err
+	}
+	if sqlDB, err := db.DB(); err == nil {
+		defer sqlDB.Close()
+	}
+
+	tableName := opts.Table
+	if tableName == "" {
+		tableName = GetUserInput("表名", "users")
+	}
+
+	schema, err := ReadTableSchema(db, tableName)
+	if err != nil {
+		return nil, err
+	}
+	// 生成的模型和BaseDAO的查询固定使用 id 作为主键列
+	if schema.PrimaryKey != "id" {
+		return nil, fmt.Errorf("表 %s 的主键列为 %s，暂只支持主键列名为 id 的表", tableName, schema.PrimaryKey)
+	}
+
+	config := &ModelConfig{
+		TableName:     tableName,
+		ProjectImport: readModuleName(filepath.Join(projectRoot, "go.mod")),
+		ID:            schema.IDType,
+		DBType:        dbConfig.Type,
+	}
+	for _, field := range schema.Fields {
+		// 已有的整数 version 列作为乐观锁版本号
+		if field.Column == "version" && (field.QueryType() == "ColumnInt" || field.QueryType() == "ColumnUint") {
+			config.Versioned = true
+			continue
+		}
+		config.Fields = append(config.Fields, field)
+	}
+	if err := normalizeModelConfig(config); err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("从表 %s 读取到 %d 个字段：\n", tableName, len(config.Fields))
+	if config.Versioned {
+		fmt.Println("  (version 列作为乐观锁版本号)")
+	}
+	for _, field := range config.Fields {
+		fmt.Printf("  %s %s %s\n", field.Name, field.Type, field.StructTag())
+	}
+	return config, nil
+}
+
+// 根据表名推导默认模型名称（首字母大写，去掉复数形式）
+func defaultModelName(tableName string) string {
+	modelName := strings.ToUpper(tableName[:1]) + tableName[1:]
+	if strings.HasSuffix(modelName, "s") {
+		modelName = modelName[:len(modelName)-1] // 去掉复数形式
+	}
+	return modelName
+}
+
+// 根据模型名称推导默认模块名（首字母小写）
+func defaultModuleName(modelName string) string {
+	return strings.ToLower(modelName[:1]) + modelName[1:]
+}
 
-	// 模板目录
-	templatesDir := filepath.Join("scripts", "generator", "templates")
+// 根据模型配置生成代码文件
+func generateCode(config ModelConfig, projectRoot, templatesDir string) error {
+	moduleName := config.ModuleName
+	if err := resolveRelations(&config, projectRoot); err != nil {
+		return fmt.Errorf("关联关系无效: %v", err)
+	}
 
 	// 生成模型文件
 	modelPath := filepath.Join(projectRoot, "internal", "model", strings.ToLower(moduleName)+".go")
-	err := GenerateFileFromTemplate(modelPath, filepath.Join(templatesDir, "model.tmpl"), config)
+	err := GenerateFileFromTemplate(modelPath, templatesDir, "model.tmpl", config)
 	if err != nil {
-		fmt.Printf("生成模型文件失败: %v\n", err)
-		os.Exit(1)
+		return fmt.Errorf("生成模型文件失败: %v", err)
 	}
 
 	// 生成DAO文件
 	daoPath := filepath.Join(projectRoot, "internal", "dao", strings.ToLower(moduleName)+"_dao.go")
-	err = GenerateFileFromTemplate(daoPath, filepath.Join(templatesDir, "dao.tmpl"), config)
+	err = GenerateFileFromTemplate(daoPath, templatesDir, "dao.tmpl", config)
 	if err != nil {
-		fmt.Printf("生成DAO文件失败: %v\n", err)
-		os.Exit(1)
+		return fmt.Errorf("生成DAO文件失败: %v", err)
 	}
 
 	// 生成Service文件
 	servicePath := filepath.Join(projectRoot, "internal", "service", strings.ToLower(moduleName)+"_service.go")
-	err = GenerateFileFromTemplate(servicePath, filepath.Join(templatesDir, "service.tmpl"), config)
+	err = GenerateFileFromTemplate(servicePath, templatesDir, "service.tmpl", config)
 	if err != nil {
-		fmt.Printf("生成Service文件失败: %v\n", err)
-		os.Exit(1)
+		return fmt.Errorf("生成Service文件失败: %v", err)
 	}
 
 	// 生成Handler文件
 	handlerPath := filepath.Join(projectRoot, "internal", "api", strings.ToLower(moduleName)+"_handler.go")
-	err = GenerateFileFromTemplate(handlerPath, filepath.Join(templatesDir, "handler.tmpl"), config)
+	err = GenerateFileFromTemplate(handlerPath, templatesDir, "handler.tmpl", config)
 	if err != nil {
-		fmt.Printf("生成Handler文件失败: %v\n", err)
-		os.Exit(1)
+		return fmt.Errorf("生成Handler文件失败: %v", err)
 	}
 
 	// 更新Wire Provider
 	wireProviderPath := filepath.Join(projectRoot, "pkg", "wire", "provider.go")
 
 	// 更新Wire Provider文件
-	err = UpdateWireProvider(wireProviderPath, modelName, tableName, moduleName)
+	err = UpdateWireProvider(wireProviderPath, templatesDir, config)
+	if errors.Is(err, ErrProviderRegistered) {
+		// 重新生成已有模块时不重复注册
+		fmt.Printf("提示: %v，跳过更新Wire依赖注入\n", err)
+	} else if err != nil {
+		return fmt.Errorf("更新Wire Provider失败: %v", err)
+	}
+
+	// 更新接口文档，响应结构按项目配置的响应风格生成
+	openAPIPath := filepath.Join(projectRoot, "api", "openapi.yaml")
+	responseStyle := LoadResponseStyle(filepath.Join(projectRoot, "config", "config.yaml"))
+	err = UpdateOpenAPI(openAPIPath, templatesDir, config, responseStyle)
 	if err != nil {
-		// 尝试更新ProviderSet
-		updateErr := UpdateProviderSet(wireProviderPath, modelName+"Set")
-		if updateErr != nil {
-			fmt.Printf("更新Wire Provider失败: %v\n", updateErr)
-			os.Exit(1)
+		return fmt.Errorf("更新接口文档失败: %v", err)
+	}
+
+	// 生成Go和TypeScript客户端
+	err = GenerateClients(projectRoot, templatesDir, config, responseStyle)
+	if err != nil {
+		return fmt.Errorf("生成客户端失败: %v", err)
+	}
+
+	// 项目启用gRPC时生成proto和gRPC服务
+	grpcPort := LoadGRPCPort(filepath.Join(projectRoot, "config", "config.yaml"))
+	if grpcPort != "" {
+		err = GenerateGRPC(projectRoot, templatesDir, config)
+		if err != nil {
+			return fmt.Errorf("生成gRPC服务失败: %v", err)
+		}
+	}
+
+	// 项目启用GraphQL时生成schema片段和解析器
+	graphQLEnabled := LoadGraphQLEnabled(filepath.Join(projectRoot, "config", "config.yaml"))
+	if graphQLEnabled {
+		err = GenerateGraphQL(projectRoot, templatesDir, config)
+		if err != nil {
+			return fmt.Errorf("生成GraphQL接口失败: %v", err)
 		}
 	}
 
+	// 注册API路由
+	routerPath := filepath.Join(projectRoot, "internal", "api", "router.go")
+	err = UpdateRouter(routerPath, templatesDir, config)
+	if errors.Is(err, ErrRouteRegistered) {
+		fmt.Printf("提示: %v，跳过注册路由\n", err)
+	} else if err != nil {
+		return fmt.Errorf("注册API路由失败: %v", err)
+	}
+
+	if fileutil.Preview() {
+		fmt.Println("\n预览完成，未写入任何文件")
+		return nil
+	}
+
 	fmt.Println("\n代码生成成功！")
 	fmt.Printf("模型文件: %s\n", modelPath)
 	fmt.Printf("DAO文件: %s\n", daoPath)
 	fmt.Printf("Service文件: %s\n", servicePath)
 	fmt.Printf("Handler文件: %s\n", handlerPath)
-	fmt.Println("\n已更新Wire依赖注入")
+	fmt.Printf("客户端: %s\n", filepath.Join(projectRoot, "client"))
+	if grpcPort != "" {
+		fmt.Printf("gRPC服务: %s\n", filepath.Join(projectRoot, "internal", "grpcserver", strings.ToLower(moduleName)+".go"))
+	}
+	if graphQLEnabled {
+		fmt.Printf("GraphQL解析器: %s\n", filepath.Join(projectRoot, "internal", "gql", strings.ToLower(moduleName)+".go"))
+	}
+	fmt.Println("\n已更新Wire依赖注入、API路由和接口文档")
+	return nil
 }
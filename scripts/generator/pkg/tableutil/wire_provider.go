@@ -0,0 +1,236 @@
+package tableutil
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"sort"
+	"strconv"
+	"text/template"
+
+	"github.com/liam/go_web_quick_start/scripts/generator/pkg/fileutil"
+	"github.com/liam/go_web_quick_start/scripts/generator/templates"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// 全局依赖注入集合的变量名
+const providerSetName = "ProviderSet"
+
+// ErrProviderRegistered 模块的依赖注入集合已注册到ProviderSet
+var ErrProviderRegistered = errors.New("已注册到 " + providerSetName)
+
+// UpdateWireProvider 将新模块的依赖注入集合注册到wire provider文件
+// 使用go/ast解析provider.go：合并wire_provider.tmpl中的导入，追加其中的声明，
+// 并将 XxxSet 加入 ProviderSet。模块已注册时返回错误
+func UpdateWireProvider(filePath, templatesDir string, config ModelConfig) error {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("读取文件失败: %v", err)
+	}
+
+	// 生成新模块的wire代码
+	templateContent, err := templates.Load(templatesDir, "wire_provider.tmpl")
+	if err != nil {
+		return err
+	}
+	tmpl, err := template.New("wire_provider.tmpl").Parse(string(templateContent))
+	if err != nil {
+		return fmt.Errorf("解析Wire模板失败: %v", err)
+	}
+	var wireContent bytes.Buffer
+	if err := tmpl.Execute(&wireContent, config); err != nil {
+		return fmt.Errorf("生成Wire内容失败: %v", err)
+	}
+
+	updated, err := registerProviderSet(filePath, content, wireContent.Bytes(), config.ModelName+"Set")
+	if err != nil {
+		return err
+	}
+
+	// 写回文件
+	return fileutil.WriteFile(filePath, updated)
+}
+
+// 将wire代码片段合并到provider文件中，并把setName注册到ProviderSet
+func registerProviderSet(filePath string, content, snippet []byte, setName string) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filePath, content, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("解析 %s 失败: %v", filePath, err)
+	}
+	snippetFile, err := parser.ParseFile(fset, "wire_provider.tmpl", snippet, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("解析Wire模板生成的代码失败: %v", err)
+	}
+
+	providerSet, providerDecl := findProviderSet(file)
+	if providerSet == nil {
+		return nil, fmt.Errorf("%s 中未找到 %s = wire.NewSet(...) 定义", filePath, providerSetName)
+	}
+	for _, arg := range providerSet.Args {
+		if ident, ok := arg.(*ast.Ident); ok && ident.Name == setName {
+			return nil, fmt.Errorf("%s %w", setName, ErrProviderRegistered)
+		}
+	}
+
+	// 模板中的声明不能与已有声明重名
+	existing := topLevelNames(file)
+	for name := range topLevelNames(snippetFile) {
+		if existing[name] {
+			return nil, fmt.Errorf("%s 中已存在 %s 的声明", filePath, name)
+		}
+	}
+
+	// 按源码偏移插入文本，从后往前插入以免影响前面的偏移
+	var edits []textEdit
+
+	// 将 setName 加入 ProviderSet 参数列表
+	edits = append(edits, appendArgEdit(fset, content, providerSet, setName))
+
+	// 模板中除package和import外的声明插入到ProviderSet定义之前
+	declStart := declsOffset(fset, snippetFile)
+	if declStart >= 0 {
+		edits = append(edits, textEdit{
+			offset: nodeOffset(fset, providerDecl),
+			text:   string(bytes.TrimSpace(snippet[declStart:])) + "\n\n",
+		})
+	}
+
+	updated := applyEdits(content, edits)
+
+	// 合并导入
+	file, err = parser.ParseFile(fset, filePath, updated, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("合并后的 %s 无效: %v", filePath, err)
+	}
+	for _, spec := range snippetFile.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		name := ""
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		astutil.AddNamedImport(fset, file, name, path)
+	}
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, fmt.Errorf("格式化 %s 失败: %v", filePath, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// 查找 var ProviderSet = wire.NewSet(...)，返回调用表达式和所在声明
+func findProviderSet(file *ast.File) (*ast.CallExpr, *ast.GenDecl) {
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			valueSpec := spec.(*ast.ValueSpec)
+			for i, name := range valueSpec.Names {
+				if name.Name != providerSetName || i >= len(valueSpec.Values) {
+					continue
+				}
+				call, ok := valueSpec.Values[i].(*ast.CallExpr)
+				if !ok {
+					continue
+				}
+				if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "NewSet" {
+					return call, genDecl
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// 文件中的顶层声明名称
+func topLevelNames(file *ast.File) map[string]bool {
+	names := make(map[string]bool)
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv == nil {
+				names[d.Name.Name] = true
+			}
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.ValueSpec:
+					for _, name := range s.Names {
+						names[name.Name] = true
+					}
+				case *ast.TypeSpec:
+					names[s.Name.Name] = true
+				}
+			}
+		}
+	}
+	return names
+}
+
+// 文本插入
+type textEdit struct {
+	offset int
+	text   string
+}
+
+// 在ProviderSet参数列表末尾追加参数
+func appendArgEdit(fset *token.FileSet, content []byte, call *ast.CallExpr, arg string) textEdit {
+	if len(call.Args) == 0 {
+		return textEdit{offset: fset.Position(call.Lparen).Offset + 1, text: "\n\t" + arg + ",\n"}
+	}
+
+	last := call.Args[len(call.Args)-1]
+	lastEnd := fset.Position(last.End())
+	if lastEnd.Line == fset.Position(call.Rparen).Line {
+		// 单行写法：wire.NewSet(A, B)
+		return textEdit{offset: lastEnd.Offset, text: ", " + arg}
+	}
+
+	// 多行写法：插入到最后一个参数所在行之后
+	return textEdit{offset: lineEnd(content, lastEnd.Offset), text: "\n\t" + arg + ","}
+}
+
+// 代码片段中第一个非import声明（含文档注释）的偏移，没有时返回-1
+func declsOffset(fset *token.FileSet, file *ast.File) int {
+	for _, decl := range file.Decls {
+		if genDecl, ok := decl.(*ast.GenDecl); ok && genDecl.Tok == token.IMPORT {
+			continue
+		}
+		return nodeOffset(fset, decl)
+	}
+	return -1
+}
+
+// 声明的起始偏移，包含文档注释
+func nodeOffset(fset *token.FileSet, decl ast.Decl) int {
+	pos := decl.Pos()
+	switch d := decl.(type) {
+	case *ast.GenDecl:
+		if d.Doc != nil {
+			pos = d.Doc.Pos()
+		}
+	case *ast.FuncDecl:
+		if d.Doc != nil {
+			pos = d.Doc.Pos()
+		}
+	}
+	return fset.Position(pos).Offset
+}
+
+// 按偏移从后往前应用文本插入
+func applyEdits(content []byte, edits []textEdit) []byte {
+	sort.Slice(edits, func(i, j int) bool { return edits[i].offset > edits[j].offset })
+	result := append([]byte(nil), content...)
+	for _, edit := range edits {
+		result = append(result[:edit.offset], append([]byte(edit.text), result[edit.offset:]...)...)
+	}
+	return result
+}
@@ -0,0 +1,244 @@
+package tableutil
+
+import (
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// 新项目中的 provider.go
+const providerFixture = `package wire
+
+import (
+	"github.com/google/wire"
+	"example.com/app/internal/dao"
+	"example.com/app/internal/service"
+)
+
+// UserSet 用户模块依赖注入
+var UserSet = wire.NewSet(
+	dao.NewUserDAO,
+	service.NewUserService,
+)
+
+// ProviderSet 全局依赖注入集合
+var ProviderSet = wire.NewSet(
+	UserSet,
+	// 在这里添加其他模块的依赖注入Set
+)
+`
+
+const providerSnippet = `package wire
+
+import (
+	"github.com/google/wire"
+	"example.com/app/internal/dao"
+	"example.com/app/internal/service"
+)
+
+// PostSet posts模块依赖注入
+var PostSet = wire.NewSet(
+	dao.NewPostDAO,
+	service.NewPostService,
+)
+`
+
+func TestRegisterProviderSet(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		setName string
+		wantErr string // 为空时应成功
+	}{
+		{name: "注册新模块", content: providerFixture, setName: "PostSet"},
+		{name: "重复注册", content: providerFixture, setName: "UserSet", wantErr: ErrProviderRegistered.Error()},
+		{name: "缺少ProviderSet", content: "package wire\n", setName: "PostSet", wantErr: "未找到 ProviderSet"},
+		{
+			name:    "声明重名",
+			content: strings.Replace(providerFixture, "// ProviderSet", "var PostSet = wire.NewSet()\n\n// ProviderSet", 1),
+			setName: "PostSet",
+			wantErr: "已存在 PostSet 的声明",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated, err := registerProviderSet("provider.go", []byte(tt.content), []byte(providerSnippet), tt.setName)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("registerProviderSet 的错误为 %v, 期望包含 %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("registerProviderSet 返回错误: %v", err)
+			}
+			if _, _, args := parseProviderFile(t, updated); !reflect.DeepEqual(args, []string{"UserSet", "PostSet"}) {
+				t.Errorf("ProviderSet 参数 = %v, 期望 [UserSet PostSet]:\n%s", args, updated)
+			}
+			file, err := parser.ParseFile(token.NewFileSet(), "provider.go", updated, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !topLevelNames(file)["PostSet"] {
+				t.Errorf("合并结果中缺少 PostSet 的声明:\n%s", updated)
+			}
+		})
+	}
+}
+
+// 解析合并结果，返回import声明的数量、导入路径和ProviderSet的参数
+func parseProviderFile(t *testing.T, content []byte) (int, []string, []string) {
+	t.Helper()
+	file, err := parser.ParseFile(token.NewFileSet(), "provider.go", content, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("合并结果无法解析: %v\n%s", err, content)
+	}
+	importDecls := 0
+	for _, decl := range file.Decls {
+		if genDecl, ok := decl.(*ast.GenDecl); ok && genDecl.Tok == token.IMPORT {
+			importDecls++
+		}
+	}
+	var paths []string
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		paths = append(paths, path)
+	}
+	call, _ := findProviderSet(file)
+	if call == nil {
+		t.Fatalf("合并结果中没有 ProviderSet:\n%s", content)
+	}
+	var args []string
+	for _, arg := range call.Args {
+		args = append(args, types.ExprString(arg))
+	}
+	return importDecls, paths, args
+}
+
+func TestRegisterProviderSetMerge(t *testing.T) {
+	const header = "package wire\n\nimport (\n\t\"github.com/google/wire\"\n\t\"example.com/app/internal/dao\"\n\t\"example.com/app/internal/service\"\n)\n\n"
+	wireDAOService := []string{"example.com/app/internal/dao", "example.com/app/internal/service", "github.com/google/wire"}
+	tests := []struct {
+		name      string
+		content   string
+		wantPaths []string // 按字母排序
+		wantArgs  []string
+	}{
+		{
+			name:      "多行参数",
+			content:   providerFixture,
+			wantPaths: wireDAOService,
+			wantArgs:  []string{"UserSet", "PostSet"},
+		},
+		{
+			name:      "单行参数",
+			content:   header + "var ProviderSet = wire.NewSet(UserSet)\n",
+			wantPaths: wireDAOService,
+			wantArgs:  []string{"UserSet", "PostSet"},
+		},
+		{
+			name:      "空参数",
+			content:   header + "var ProviderSet = wire.NewSet()\n",
+			wantPaths: wireDAOService,
+			wantArgs:  []string{"PostSet"},
+		},
+		{
+			name:      "参数中有嵌套调用",
+			content:   header + "var ProviderSet = wire.NewSet(UserSet, wire.Bind(new(Cache), new(*RedisCache)))\n",
+			wantPaths: wireDAOService,
+			wantArgs:  []string{"UserSet", "wire.Bind(new(Cache), new(*RedisCache))", "PostSet"},
+		},
+		{
+			name:      "最后一个参数跨多行且与右括号同行",
+			content:   header + "var ProviderSet = wire.NewSet(UserSet, wire.Struct(\n\tnew(Config), \"*\"))\n",
+			wantPaths: wireDAOService,
+			wantArgs:  []string{"UserSet", `wire.Struct(new(Config), "*")`, "PostSet"},
+		},
+		{
+			name:      "最后一个参数跨多行",
+			content:   header + "var ProviderSet = wire.NewSet(\n\tUserSet,\n\twire.Struct(\n\t\tnew(Config),\n\t\t\"*\",\n\t), // 配置\n)\n",
+			wantPaths: wireDAOService,
+			wantArgs:  []string{"UserSet", `wire.Struct(new(Config), "*")`, "PostSet"},
+		},
+		{
+			name:      "var块中的ProviderSet",
+			content:   header + "var (\n\t// ProviderSet 全局依赖注入集合\n\tProviderSet = wire.NewSet(\n\t\tUserSet,\n\t)\n)\n",
+			wantPaths: wireDAOService,
+			wantArgs:  []string{"UserSet", "PostSet"},
+		},
+		{
+			name:      "单行导入",
+			content:   "package wire\n\nimport \"github.com/google/wire\"\n\nvar ProviderSet = wire.NewSet()\n",
+			wantPaths: wireDAOService,
+			wantArgs:  []string{"PostSet"},
+		},
+		{
+			name: "已有部分导入和其他导入",
+			content: "package wire\n\nimport (\n\t\"github.com/google/wire\"\n\n\t\"example.com/app/internal/cache\"\n\t\"example.com/app/internal/dao\"\n)\n\n" +
+				"var ProviderSet = wire.NewSet(cache.New, dao.NewUserDAO)\n",
+			wantPaths: []string{"example.com/app/internal/cache", "example.com/app/internal/dao", "example.com/app/internal/service", "github.com/google/wire"},
+			wantArgs:  []string{"cache.New", "dao.NewUserDAO", "PostSet"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated, err := registerProviderSet("provider.go", []byte(tt.content), []byte(providerSnippet), "PostSet")
+			if err != nil {
+				t.Fatalf("registerProviderSet 返回错误: %v", err)
+			}
+			importDecls, paths, args := parseProviderFile(t, updated)
+			if importDecls != 1 {
+				t.Errorf("import 声明有 %d 个, 期望 1 个:\n%s", importDecls, updated)
+			}
+			sort.Strings(paths)
+			if !reflect.DeepEqual(paths, tt.wantPaths) {
+				t.Errorf("导入路径 = %v, 期望 %v:\n%s", paths, tt.wantPaths, updated)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("ProviderSet 参数 = %v, 期望 %v:\n%s", args, tt.wantArgs, updated)
+			}
+		})
+	}
+}
+
+func TestUpdateWireProviderIdempotent(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "provider.go")
+	if err := os.WriteFile(filePath, []byte(providerFixture), 0644); err != nil {
+		t.Fatal(err)
+	}
+	config := ModelConfig{ProjectImport: "example.com/app", TableName: "posts", ModelName: "Post"}
+
+	if err := UpdateWireProvider(filePath, "", config); err != nil {
+		t.Fatalf("第一次注册返回错误: %v", err)
+	}
+	first, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = UpdateWireProvider(filePath, "", config)
+	if !errors.Is(err, ErrProviderRegistered) {
+		t.Fatalf("重复注册应返回 ErrProviderRegistered, 实际为 %v", err)
+	}
+	second, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(first) != string(second) {
+		t.Errorf("重复注册不应修改文件:\n%s", second)
+	}
+	if _, _, args := parseProviderFile(t, second); !reflect.DeepEqual(args, []string{"UserSet", "PostSet"}) {
+		t.Errorf("ProviderSet 参数 = %v, 期望 [UserSet PostSet]", args)
+	}
+}
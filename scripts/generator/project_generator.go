@@ -2,6 +2,9 @@ package main
 
 import (
 	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -9,85 +12,13 @@ import (
 	"text/template"
 
 	"github.com/liam/go_web_quick_start/scripts/generator/model"
+	"github.com/liam/go_web_quick_start/scripts/generator/pkg/fileutil"
+	"github.com/liam/go_web_quick_start/scripts/generator/pkg/tableutil"
+	"github.com/liam/go_web_quick_start/scripts/generator/templates"
+	"gopkg.in/yaml.v3"
 )
 
 // 模板文件内容
-const baseServiceTemplate = `package service
-
-import (
-	"gorm.io/gorm"
-)
-
-// BaseService 提供基础CRUD操作
-type BaseService struct {
-	DB *gorm.DB
-}
-
-// Create 创建记录
-func (s *BaseService) Create(model interface{}) error {
-	return s.DB.Create(model).Error
-}
-
-// FindByID 根据ID查找记录
-func (s *BaseService) FindByID(id uint, out interface{}) error {
-	return s.DB.First(out, id).Error
-}
-
-// Update 更新记录
-func (s *BaseService) Update(model interface{}) error {
-	return s.DB.Save(model).Error
-}
-
-// Delete 删除记录
-func (s *BaseService) Delete(model interface{}) error {
-	return s.DB.Delete(model).Error
-}
-
-// List 列出所有记录
-func (s *BaseService) List(out interface{}, page, pageSize int) error {
-	offset := (page - 1) * pageSize
-	return s.DB.Offset(offset).Limit(pageSize).Find(out).Error
-}
-`
-
-const baseDaoTemplate = `package dao
-
-import (
-	"gorm.io/gorm"
-)
-
-// BaseDAO 提供基础数据访问操作
-type BaseDAO struct {
-	DB *gorm.DB
-}
-
-// Create 创建记录
-func (d *BaseDAO) Create(model interface{}) error {
-	return d.DB.Create(model).Error
-}
-
-// FindByID 根据ID查找记录
-func (d *BaseDAO) FindByID(id uint, out interface{}) error {
-	return d.DB.First(out, id).Error
-}
-
-// Update 更新记录
-func (d *BaseDAO) Update(model interface{}) error {
-	return d.DB.Save(model).Error
-}
-
-// Delete 删除记录
-func (d *BaseDAO) Delete(model interface{}) error {
-	return d.DB.Delete(model).Error
-}
-
-// List 列出所有记录
-func (d *BaseDAO) List(out interface{}, page, pageSize int) error {
-	offset := (page - 1) * pageSize
-	return d.DB.Offset(offset).Limit(pageSize).Find(out).Error
-}
-`
-
 const configLoaderTemplate = `package config
 
 import (
@@ -100,7 +31,8 @@ import (
 
 // InitConfig 初始化配置
 func InitConfig() *viper.Viper {
-	v := viper.New()
+	// 使用全局实例，其他包通过 viper.GetString 读取配置
+	v := viper.GetViper()
 	v.SetConfigName("config")
 	v.SetConfigType("yaml")
 	v.AddConfigPath("./config")
@@ -118,7 +50,8 @@ func InitConfig() *viper.Viper {
 		defaultConfig := []byte("# 应用配置\n" +
 			"app:\n" +
 			"  name: myapp\n" +
-			"  port: 8080\n\n" +
+			"  port: 8080\n" +
+			"  response_style: envelope\n\n" +
 			"# 数据库配置\n" +
 			"database:\n" +
 			"  type: mysql\n" +
@@ -136,7 +69,10 @@ func InitConfig() *viper.Viper {
 			"  port: 6379\n" +
 			"  password: \n" +
 			"  db: 0\n" +
-			"  pool_size: 100\n")
+			"  pool_size: 100\n\n" +
+			"# 管理接口配置\n" +
+			"admin:\n" +
+			"  token: \"\"\n")
 		
 		if err := os.WriteFile("./config/config.yaml", defaultConfig, 0644); err != nil {
 			log.Fatalf("创建默认配置文件失败: %v", err)
@@ -363,13 +299,19 @@ func copyFile(src, dst string) error {
 func createProjectStructure(config model.ProjectConfig) error {
 	// 创建主要目录
 	directories := []string{
+		"api",
+		"client",
+		"client/typescript",
 		"cmd",
 		"config",
 		"internal/api",
 		"internal/dao",
+		"internal/errors",
 		"internal/middleware",
 		"internal/model",
+		"internal/response",
 		"internal/service",
+		"internal/swagger",
 		"pkg/cache",
 		"pkg/config",
 		"pkg/database",
@@ -383,17 +325,12 @@ func createProjectStructure(config model.ProjectConfig) error {
 
 	for _, dir := range directories {
 		path := filepath.Join(config.ProjectPath, dir)
-		err := os.MkdirAll(path, 0755)
+		err := fileutil.MkdirAll(path)
 		if err != nil {
 			return fmt.Errorf("创建目录 %s 失败: %v", path, err)
 		}
 	}
 
-	// 先创建临时模板文件
-	if err := createTemplatesFiles(config.ProjectPath); err != nil {
-		return err
-	}
-
 	// 创建配置文件
 	configPath := filepath.Join(config.ProjectPath, "config", "config.yaml")
 	err := generateFromTemplate(configPath, "config.tmpl", config)
@@ -410,14 +347,129 @@ func createProjectStructure(config model.ProjectConfig) error {
 
 	// 创建基础服务层
 	baseServicePath := filepath.Join(config.ProjectPath, "internal", "service", "base_service.go")
-	err = createFileFromTemplate(baseServicePath, baseServiceTemplate, config)
+	err = generateFromTemplate(baseServicePath, "base_service.tmpl", config)
 	if err != nil {
 		return err
 	}
 
 	// 创建基础数据访问层
 	baseDaoPath := filepath.Join(config.ProjectPath, "internal", "dao", "base_dao.go")
-	err = createFileFromTemplate(baseDaoPath, baseDaoTemplate, config)
+	err = generateFromTemplate(baseDaoPath, "base_dao.tmpl", config)
+	if err != nil {
+		return err
+	}
+
+	// 创建事务管理
+	txPath := filepath.Join(config.ProjectPath, "internal", "dao", "tx.go")
+	err = generateFromTemplate(txPath, "tx.tmpl", config)
+	if err != nil {
+		return err
+	}
+
+	// 创建通用查询条件
+	queryPath := filepath.Join(config.ProjectPath, "internal", "dao", "query.go")
+	err = generateFromTemplate(queryPath, "query.tmpl", config)
+	if err != nil {
+		return err
+	}
+
+	// 创建领域错误
+	errorsPath := filepath.Join(config.ProjectPath, "internal", "errors", "errors.go")
+	err = generateFromTemplate(errorsPath, "errors.tmpl", config)
+	if err != nil {
+		return err
+	}
+
+	// 创建统一响应
+	responsePath := filepath.Join(config.ProjectPath, "internal", "response", "response.go")
+	err = generateFromTemplate(responsePath, "response.tmpl", config)
+	if err != nil {
+		return err
+	}
+
+	// 创建请求ID中间件
+	requestIDMiddlewarePath := filepath.Join(config.ProjectPath, "internal", "middleware", "request_id.go")
+	err = generateFromTemplate(requestIDMiddlewarePath, "request_id_middleware.tmpl", config)
+	if err != nil {
+		return err
+	}
+
+	// 创建错误响应中间件
+	errorMiddlewarePath := filepath.Join(config.ProjectPath, "internal", "middleware", "error.go")
+	err = generateFromTemplate(errorMiddlewarePath, "error_middleware.tmpl", config)
+	if err != nil {
+		return err
+	}
+
+	// 创建管理员权限中间件
+	adminMiddlewarePath := filepath.Join(config.ProjectPath, "internal", "middleware", "admin.go")
+	err = generateFromTemplate(adminMiddlewarePath, "admin_middleware.tmpl", config)
+	if err != nil {
+		return err
+	}
+
+	// 创建接口文档，包含用户接口，生成表代码时继续更新
+	openAPIPath := filepath.Join(config.ProjectPath, "api", "openapi.yaml")
+	err = tableutil.UpdateOpenAPI(openAPIPath, customTemplatesDir, userModelConfig(config), config.ResponseStyle)
+	if err != nil {
+		return fmt.Errorf("创建接口文档失败: %v", err)
+	}
+
+	openAPIEmbedPath := filepath.Join(config.ProjectPath, "api", "openapi.go")
+	err = generateFromTemplate(openAPIEmbedPath, "openapi_embed.tmpl", config)
+	if err != nil {
+		return err
+	}
+
+	// 创建Go和TypeScript客户端，包含用户接口，生成表代码时继续添加
+	clientPath := filepath.Join(config.ProjectPath, "client", "client.go")
+	err = generateFromTemplate(clientPath, "client_base.tmpl", config)
+	if err != nil {
+		return err
+	}
+
+	tsClientPath := filepath.Join(config.ProjectPath, "client", "typescript", "client.ts")
+	err = generateFromTemplate(tsClientPath, "client_base_ts.tmpl", config)
+	if err != nil {
+		return err
+	}
+
+	err = tableutil.GenerateClients(config.ProjectPath, customTemplatesDir, userModelConfig(config), config.ResponseStyle)
+	if err != nil {
+		return fmt.Errorf("创建客户端失败: %v", err)
+	}
+
+	// 启用gRPC时创建用户服务的proto和gRPC服务，生成表代码时继续添加
+	if config.GRPCPort != "" {
+		bufPath := filepath.Join(config.ProjectPath, "proto", "buf.yaml")
+		err = generateFromTemplate(bufPath, "buf.tmpl", config)
+		if err != nil {
+			return err
+		}
+
+		bufGenPath := filepath.Join(config.ProjectPath, "buf.gen.yaml")
+		err = generateFromTemplate(bufGenPath, "buf_gen.tmpl", config)
+		if err != nil {
+			return err
+		}
+
+		err = tableutil.GenerateGRPC(config.ProjectPath, customTemplatesDir, userModelConfig(config))
+		if err != nil {
+			return fmt.Errorf("创建gRPC服务失败: %v", err)
+		}
+	}
+
+	// 启用GraphQL时创建用户的schema和解析器，生成表代码时继续添加
+	if config.GraphQLEnabled() {
+		err = tableutil.GenerateGraphQL(config.ProjectPath, customTemplatesDir, userModelConfig(config))
+		if err != nil {
+			return fmt.Errorf("创建GraphQL接口失败: %v", err)
+		}
+	}
+
+	// 创建Swagger UI路由
+	swaggerPath := filepath.Join(config.ProjectPath, "internal", "swagger", "swagger.go")
+	err = generateFromTemplate(swaggerPath, "swagger.tmpl", config)
 	if err != nil {
 		return err
 	}
@@ -512,16 +564,21 @@ func main() {
 	fmt.Println("功能待实现")
 }
 `
-	err = os.WriteFile(tableGeneratorPath, []byte(simpleTplContent), 0644)
+	err = fileutil.WriteFile(tableGeneratorPath, []byte(simpleTplContent))
 	if err != nil {
 		return fmt.Errorf("创建表生成器文件失败: %v", err)
 	}
-	fmt.Println("创建了简单的表生成器文件")
 
 	// 创建go.mod文件
-	goModContent := fmt.Sprintf("module %s\n\ngo 1.20\n\nrequire (\n\tgithub.com/fsnotify/fsnotify v1.7.0\n\tgithub.com/gin-gonic/gin v1.9.1\n\tgithub.com/go-redis/redis/v8 v8.11.5\n\tgithub.com/google/wire v0.5.0\n\tgithub.com/sijms/go-ora/v2 v2.7.31\n\tgithub.com/spf13/viper v1.18.2\n\tgo.uber.org/zap v1.26.0\n\tgorm.io/driver/mysql v1.5.2\n\tgorm.io/driver/postgres v1.5.4\n\tgorm.io/driver/sqlite v1.5.4\n\tgorm.io/driver/sqlserver v1.5.2\n\tgorm.io/gorm v1.25.5\n\tgolang.org/x/crypto v0.20.0\n)\n", config.ProjectName)
+	goModContent := fmt.Sprintf("module %s\n\ngo 1.20\n\nrequire (\n\tgithub.com/fsnotify/fsnotify v1.7.0\n\tgithub.com/gin-gonic/gin v1.9.1\n\tgithub.com/go-playground/validator/v10 v10.14.0\n\tgithub.com/go-redis/redis/v8 v8.11.5\n\tgithub.com/google/wire v0.5.0\n\tgithub.com/sijms/go-ora/v2 v2.7.31\n\tgithub.com/spf13/viper v1.18.2\n\tgithub.com/swaggo/files v1.0.1\n\tgo.uber.org/zap v1.26.0\n\tgorm.io/driver/mysql v1.5.2\n\tgorm.io/driver/postgres v1.5.4\n\tgorm.io/driver/sqlite v1.5.4\n\tgorm.io/driver/sqlserver v1.5.2\n\tgorm.io/gorm v1.25.5\n\tgolang.org/x/crypto v0.20.0\n)\n", config.ProjectName)
+	if config.GRPCPort != "" {
+		goModContent = strings.Replace(goModContent, ")\n", "\tgoogle.golang.org/grpc v1.59.0\n\tgoogle.golang.org/protobuf v1.31.0\n)\n", 1)
+	}
+	if config.GraphQLEnabled() {
+		goModContent = strings.Replace(goModContent, ")\n", "\tgithub.com/graph-gophers/dataloader/v7 v7.1.0\n\tgithub.com/graph-gophers/graphql-go v1.5.0\n)\n", 1)
+	}
 	goModPath := filepath.Join(config.ProjectPath, "go.mod")
-	err = os.WriteFile(goModPath, []byte(goModContent), 0644)
+	err = fileutil.WriteFile(goModPath, []byte(goModContent))
 	if err != nil {
 		return fmt.Errorf("创建go.mod文件失败: %v", err)
 	}
@@ -529,1080 +586,216 @@ func main() {
 	return nil
 }
 
-// 创建模板文件
-func createTemplatesFiles(projectPath string) error {
-	// 获取当前工作目录
-	workDir, _ := os.Getwd()
-
-	// 创建模板目录
-	templateDir := filepath.Join(workDir, "scripts", "generator", "templates")
-	if err := os.MkdirAll(templateDir, 0755); err != nil {
-		return fmt.Errorf("创建模板目录失败: %v", err)
-	}
-
-	// 创建模板文件
-	templates := map[string]string{
-		"config.tmpl": `# 应用配置
-app:
-  name: {{.ProjectName}}
-  port: {{.ServerPort}}
-
-# 数据库配置
-database:
-  type: {{.DBType}}
-  host: {{.DBHost}}
-  port: {{.DBPort}}
-  username: {{.DBUser}}
-  password: {{.DBPassword}}
-  dbname: {{.DBName}}
-  max_idle_conns: 10
-  max_open_conns: 100
-  log_mode: true
-
-# Redis配置
-redis:
-  host: {{.RedisHost}}
-  port: {{.RedisPort}}
-  password: {{.RedisPassword}}
-  db: {{.RedisDB}}
-  pool_size: 100`,
-		"main.tmpl": `package main
-
-import (
-	"fmt"
-	"log"
-	"{{.ProjectName}}/pkg/config"
-	"{{.ProjectName}}/pkg/database"
-	"{{.ProjectName}}/pkg/cache"
-	"{{.ProjectName}}/pkg/logger"
-	"{{.ProjectName}}/internal/api"
-)
+// 自定义模板目录，其中的同名模板优先于内置模板
+var customTemplatesDir string
 
-func main() {
-	// 初始化配置
-	cfg := config.InitConfig()
-	
-	// 初始化日志
-	logger.InitLogger()
-	
-	// 初始化数据库连接
-	db := database.InitDB()
-	
-	// 初始化Redis客户端
-	redis := cache.InitRedis()
-	
-	// 初始化路由
-	router := api.InitRouter(db, redis)
-	
-	// 启动服务器
-	port := cfg.GetString("app.port")
-	log.Printf("服务启动在 http://localhost:%s", port)
-	if err := router.Run(fmt.Sprintf(":%s", port)); err != nil {
-		log.Fatalf("启动服务失败: %v", err)
+// 从模板文件生成
+func generateFromTemplate(filePath, templateName string, data interface{}) error {
+	// 读取模板文件
+	templateContent, err := templates.Load(customTemplatesDir, templateName)
+	if err != nil {
+		return err
 	}
-}`,
-		"router.tmpl": `package api
 
-import (
-	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
-	"github.com/go-redis/redis/v8"
-)
-
-// InitRouter 初始化路由
-func InitRouter(db *gorm.DB, redisClient *redis.Client) *gin.Engine {
-	r := gin.Default()
-	
-	// 中间件
-	r.Use(gin.Recovery())
-	
-	// 健康检查
-	r.GET("/ping", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"message": "pong",
-		})
-	})
-	
-	// 初始化用户相关路由
-	initUserRoutes(r, db)
-	
-	return r
+	return createFileFromTemplate(filePath, string(templateContent), data)
 }
 
-// 初始化用户相关路由
-func initUserRoutes(r *gin.Engine, db *gorm.DB) {
-	handler := NewUserHandler(db)
-	
-	userGroup := r.Group("/api/users")
-	{
-		userGroup.POST("", handler.Create)
-		userGroup.GET("/:id", handler.GetByID)
-		userGroup.PUT("/:id", handler.Update)
-		userGroup.DELETE("/:id", handler.Delete)
-		userGroup.GET("", handler.List)
+// 从模板创建文件（传入模板字符串）
+func createFileFromTemplate(filePath, templateContent string, data interface{}) error {
+	// 解析模板
+	tmpl, err := template.New(filepath.Base(filePath)).Parse(templateContent)
+	if err != nil {
+		return fmt.Errorf("解析模板失败: %v", err)
 	}
-}`,
-		"user_model.tmpl": `package model
 
-import (
-	"time"
-)
+	// 执行模板
+	var content bytes.Buffer
+	err = tmpl.Execute(&content, data)
+	if err != nil {
+		return fmt.Errorf("执行模板失败: %v", err)
+	}
 
-// User 用户模型
-type User struct {
-	ID        uint      ` + "`json:\"id\" gorm:\"primaryKey\"`" + `
-	Username  string    ` + "`json:\"username\" gorm:\"size:50;not null;unique\"`" + `
-	Password  string    ` + "`json:\"password,omitempty\" gorm:\"size:100;not null\"`" + `
-	Email     string    ` + "`json:\"email\" gorm:\"size:100;unique\"`" + `
-	CreatedAt time.Time ` + "`json:\"created_at\"`" + `
-	UpdatedAt time.Time ` + "`json:\"updated_at\"`" + `
+	// 写入文件
+	return fileutil.WriteFile(filePath, content.Bytes())
+}
+
+// 内置用户模型的字段，与user_model.tmpl保持一致，用于生成接口文档和客户端
+func userModelConfig(config model.ProjectConfig) tableutil.ModelConfig {
+	return tableutil.ModelConfig{
+		ModuleName:    "user",
+		TableName:     "users",
+		ModelName:     "User",
+		ProjectImport: config.ProjectName,
+		ID:            "uint",
+		Builtin:       true,
+		Fields: []tableutil.Field{
+			{Name: "Username", Type: "string", Column: "username", Size: 100},
+			{Name: "Email", Type: "string", Column: "email", Size: 100},
+			{Name: "Phone", Type: "string", Column: "phone", Size: 20},
+			{Name: "Status", Type: "int", Column: "status", Default: "1", Comment: "1: 正常, 0: 禁用"},
+		},
+	}
 }
 
-// TableName 指定表名
-func (User) TableName() string {
-	return "users"
-}`,
-		"user_dao.tmpl": `package dao
+// 标准输入读取器，所有提示共用以便支持管道输入
+var stdinReader = bufio.NewReader(os.Stdin)
 
-import (
-	"gorm.io/gorm"
-	"{{.ProjectName}}/internal/model"
-)
+// 获取用户输入
+func getUserInput(prompt string, defaultValue string) string {
+	if defaultValue != "" {
+		fmt.Printf("%s [%s]: ", prompt, defaultValue)
+	} else {
+		fmt.Printf("%s: ", prompt)
+	}
 
-// UserDAO 用户数据访问对象
-type UserDAO struct {
-	BaseDAO
-}
+	input, _ := stdinReader.ReadString('\n')
+	input = strings.TrimSpace(input)
 
-// NewUserDAO 创建用户DAO
-func NewUserDAO(db *gorm.DB) *UserDAO {
-	return &UserDAO{
-		BaseDAO: BaseDAO{DB: db},
+	if input == "" {
+		return defaultValue
 	}
+	return input
 }
 
-// GetByUsername 根据用户名查找用户
-func (d *UserDAO) GetByUsername(username string) (*model.User, error) {
-	var user model.User
-	err := d.DB.Where("username = ?", username).First(&user).Error
-	if err != nil {
-		return nil, err
-	}
-	return &user, nil
+// 项目配置项，可通过命令行参数、配置文件或交互输入提供
+type projectOption struct {
+	flag         string
+	prompt       string
+	defaultValue string
+	target       *string
 }
 
-// GetByEmail 根据邮箱查找用户
-func (d *UserDAO) GetByEmail(email string) (*model.User, error) {
-	var user model.User
-	err := d.DB.Where("email = ?", email).First(&user).Error
-	if err != nil {
-		return nil, err
+// 按交互提问顺序列出所有项目配置项
+func projectOptions(config *model.ProjectConfig) []projectOption {
+	defaultPath, _ := os.Getwd()
+	return []projectOption{
+		{"name", "项目名称（Go模块名）", "github.com/yourusername/myproject", &config.ProjectName},
+		{"path", "项目路径", defaultPath, &config.ProjectPath},
+		{"db-type", "数据库类型 (mysql, postgres, sqlite, sqlserver, oracle)", "mysql", &config.DBType},
+		{"db-host", "数据库主机", "localhost", &config.DBHost},
+		{"db-port", "数据库端口", "3306", &config.DBPort},
+		{"db-user", "数据库用户名", "root", &config.DBUser},
+		{"db-password", "数据库密码", "", &config.DBPassword},
+		{"db-name", "数据库名", "mydb", &config.DBName},
+		{"redis-host", "Redis主机", "localhost", &config.RedisHost},
+		{"redis-port", "Redis端口", "6379", &config.RedisPort},
+		{"redis-password", "Redis密码", "", &config.RedisPassword},
+		{"redis-db", "Redis数据库", "0", &config.RedisDB},
+		{"port", "服务器端口", "8080", &config.ServerPort},
+		{"response-style", "响应风格 (envelope, bare)", "envelope", &config.ResponseStyle},
+		{"grpc-port", "gRPC端口（留空不生成gRPC服务）", "", &config.GRPCPort},
+		{"graphql", "生成GraphQL接口 (true, false)", "false", &config.GraphQL},
+	}
+}
+
+// 从YAML/JSON文件加载项目配置
+func loadProjectConfig(path string, config *model.ProjectConfig) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("读取项目配置文件失败: %v", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(content, config)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, config)
+	default:
+		return fmt.Errorf("不支持的项目配置文件格式: %s（仅支持 .yaml、.yml、.json）", path)
 	}
-	return &user, nil
-}
-
-// FindByID 根据ID查找用户
-func (d *UserDAO) FindByID(id uint) (*model.User, error) {
-	var user model.User
-	err := d.DB.First(&user, id).Error
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("解析项目配置文件 %s 失败: %v", path, err)
 	}
-	return &user, nil
-}
-
-// ListUsers 获取用户列表
-func (d *UserDAO) ListUsers(page, pageSize int) ([]model.User, error) {
-	var users []model.User
-	offset := (page - 1) * pageSize
-	err := d.DB.Offset(offset).Limit(pageSize).Find(&users).Error
-	return users, err
-}`,
-		"user_service.tmpl": `package service
-
-import (
-	"errors"
-	"golang.org/x/crypto/bcrypt"
-	"gorm.io/gorm"
-	"{{.ProjectName}}/internal/dao"
-	"{{.ProjectName}}/internal/model"
-)
-
-// UserService 用户服务
-type UserService struct {
-	BaseService
-	userDAO *dao.UserDAO
+	return nil
 }
 
-// NewUserService 创建用户服务
-func NewUserService(db *gorm.DB) *UserService {
-	return &UserService{
-		BaseService: BaseService{DB: db},
-		userDAO:     dao.NewUserDAO(db),
+// 创建项目的主函数
+func CreateProject(args []string) {
+	config := model.ProjectConfig{}
+	options := projectOptions(&config)
+
+	// 命令行参数
+	flags := flag.NewFlagSet("generator", flag.ExitOnError)
+	configFile := flags.String("config", "", "项目配置文件(YAML/JSON)")
+	yes := flags.Bool("yes", false, "未提供的配置项直接使用默认值，不再交互")
+	dryRun := flags.Bool("dry-run", false, "只列出将创建或修改的文件，不写入")
+	showDiff := flags.Bool("diff", false, "输出与磁盘上已有文件的统一差异，不写入")
+	flags.StringVar(&customTemplatesDir, "templates", "", "自定义模板目录，其中的同名模板优先于内置模板")
+	for _, option := range options {
+		flags.String(option.flag, option.defaultValue, option.prompt)
+	}
+	flags.Parse(args)
+	fileutil.SetMode(*dryRun, *showDiff)
+
+	// 记录已提供的配置项，命令行参数优先于配置文件
+	provided := make(map[string]bool)
+	if *configFile != "" {
+		if err := loadProjectConfig(*configFile, &config); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		for _, option := range options {
+			if *option.target != "" {
+				provided[option.flag] = true
+			}
+		}
 	}
-}
+	flags.Visit(func(f *flag.Flag) {
+		for _, option := range options {
+			if option.flag == f.Name {
+				*option.target = f.Value.String()
+				provided[option.flag] = true
+			}
+		}
+	})
 
-// CreateUser 创建用户
-func (s *UserService) CreateUser(user *model.User) error {
-	// 检查用户名是否已存在
-	existingUser, _ := s.userDAO.GetByUsername(user.Username)
-	if existingUser != nil {
-		return errors.New("用户名已存在")
+	// 创建项目
+	fmt.Println("=== Go Web Quick Start 项目生成器 ===")
+	if !*yes {
+		fmt.Println("请输入以下信息来生成您的项目：")
 	}
-	
-	// 检查邮箱是否已存在
-	if user.Email != "" {
-		existingUser, _ = s.userDAO.GetByEmail(user.Email)
-		if existingUser != nil {
-			return errors.New("邮箱已存在")
+
+	// 未提供的配置项使用默认值或交互输入
+	for _, option := range options {
+		if provided[option.flag] {
+			continue
+		}
+		if *yes {
+			*option.target = option.defaultValue
+		} else {
+			*option.target = getUserInput(option.prompt, option.defaultValue)
 		}
 	}
-	
-	// 加密密码
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
-	if err != nil {
-		return err
-	}
-	user.Password = string(hashedPassword)
-	
-	return s.userDAO.Create(user)
-}
-
-// GetUserByID 根据ID获取用户
-func (s *UserService) GetUserByID(id uint) (*model.User, error) {
-	return s.userDAO.FindByID(id)
-}
-
-// UpdateUser 更新用户
-func (s *UserService) UpdateUser(user *model.User) error {
-	return s.userDAO.Update(user)
-}
 
-// DeleteUser 删除用户
-func (s *UserService) DeleteUser(id uint) error {
-	user, err := s.userDAO.FindByID(id)
-	if err != nil {
-		return err
+	// 确保项目名称格式正确
+	if !strings.Contains(config.ProjectName, "/") {
+		fmt.Println("警告: 项目名称应该是完整的模块路径，例如 github.com/username/project")
+		fmt.Println("将自动修正为标准格式...")
+		config.ProjectName = "github.com/" + config.ProjectName
 	}
-	return s.userDAO.Delete(user)
-}
 
-// ListUsers 获取用户列表
-func (s *UserService) ListUsers(page, pageSize int) ([]model.User, error) {
-	return s.userDAO.ListUsers(page, pageSize)
-}`,
-		"user_handler.tmpl": `package api
-
-import (
-	"net/http"
-	"strconv"
-	
-	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
-	
-	"{{.ProjectName}}/internal/model"
-	"{{.ProjectName}}/internal/service"
-)
-
-// UserHandler 用户处理器
-type UserHandler struct {
-	userService *service.UserService
-}
-
-// NewUserHandler 创建用户处理器
-func NewUserHandler(db *gorm.DB) *UserHandler {
-	return &UserHandler{
-		userService: service.NewUserService(db),
+	if config.ResponseStyle != "envelope" && config.ResponseStyle != "bare" {
+		fmt.Printf("不支持的响应风格: %s（仅支持 envelope、bare）\n", config.ResponseStyle)
+		os.Exit(1)
 	}
-}
 
-// Create 创建用户
-func (h *UserHandler) Create(c *gin.Context) {
-	var user model.User
-	if err := c.ShouldBindJSON(&user); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-	
-	if err := h.userService.CreateUser(&user); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
+	if config.GraphQL != "true" && config.GraphQL != "false" {
+		fmt.Printf("无效的GraphQL选项: %s（仅支持 true、false）\n", config.GraphQL)
+		os.Exit(1)
 	}
-	
-	// 清除密码
-	user.Password = ""
-	
-	c.JSON(http.StatusCreated, user)
-}
 
-// GetByID 根据ID获取用户
-func (h *UserHandler) GetByID(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := strconv.ParseUint(idStr, 10, 64)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的ID"})
-		return
-	}
-	
-	user, err := h.userService.GetUserByID(uint(id))
+	// 创建项目
+	fmt.Println("\n正在生成项目...")
+	err := createProjectStructure(config)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "用户不存在"})
-		return
+		fmt.Printf("生成项目失败: %v\n", err)
+		os.Exit(1)
 	}
-	
-	// 清除密码
-	user.Password = ""
-	
-	c.JSON(http.StatusOK, user)
-}
 
-// Update 更新用户
-func (h *UserHandler) Update(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := strconv.ParseUint(idStr, 10, 64)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的ID"})
-		return
-	}
-	
-	// 检查用户是否存在
-	user, err := h.userService.GetUserByID(uint(id))
-	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "用户不存在"})
-		return
-	}
-	
-	// 绑定请求数据
-	var updateData model.User
-	if err := c.ShouldBindJSON(&updateData); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-	
-	// 更新用户
-	user.Username = updateData.Username
-	user.Email = updateData.Email
-	if updateData.Password != "" {
-		user.Password = updateData.Password
-	}
-	
-	if err := h.userService.UpdateUser(user); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if fileutil.Preview() {
+		fmt.Println("\n预览完成，未写入任何文件")
 		return
 	}
-	
-	// 清除密码
-	user.Password = ""
-	
-	c.JSON(http.StatusOK, user)
-}
-
-// Delete 删除用户
-func (h *UserHandler) Delete(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := strconv.ParseUint(idStr, 10, 64)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的ID"})
-		return
-	}
-	
-	if err := h.userService.DeleteUser(uint(id)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-	
-	c.JSON(http.StatusOK, gin.H{"message": "用户已删除"})
-}
-
-// List 获取用户列表
-func (h *UserHandler) List(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
-	
-	users, err := h.userService.ListUsers(page, pageSize)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-	
-	// 清除所有用户的密码
-	for i := range users {
-		users[i].Password = ""
-	}
-	
-	c.JSON(http.StatusOK, users)
-}`,
-		"wire.tmpl": `package wire
-
-import (
-	"github.com/google/wire"
-)
-
-// InitApp 初始化应用依赖
-var InitApp = wire.NewSet(
-	ProvideDB,
-	ProvideRedis,
-	ProvideRouter,
-)`,
-		"wire_provider_base.tmpl": `package wire
-
-import (
-	"github.com/gin-gonic/gin"
-	"github.com/go-redis/redis/v8"
-	"gorm.io/gorm"
-	"{{.ProjectName}}/pkg/cache"
-	"{{.ProjectName}}/pkg/database"
-	"{{.ProjectName}}/internal/api"
-)
-
-// ProvideDB 提供数据库实例
-func ProvideDB() *gorm.DB {
-	return database.InitDB()
-}
-
-// ProvideRedis 提供Redis实例
-func ProvideRedis() *redis.Client {
-	return cache.InitRedis()
-}
-
-// ProvideRouter 提供路由实例
-func ProvideRouter(db *gorm.DB, redis *redis.Client) *gin.Engine {
-	return api.InitRouter(db, redis)
-}`,
-	}
-
-	// 写入模板文件
-	for name, content := range templates {
-		filePath := filepath.Join(templateDir, name)
-		if _, err := os.Stat(filePath); os.IsNotExist(err) {
-			fmt.Printf("创建模板文件: %s\n", name)
-			if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
-				return fmt.Errorf("创建模板文件 %s 失败: %v", name, err)
-			}
-		}
-	}
-
-	return nil
-}
-
-// 从模板文件生成
-func generateFromTemplate(filePath, templateName string, data interface{}) error {
-	// 确保目录存在
-	dir := filepath.Dir(filePath)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("创建目录 %s 失败: %v", dir, err)
-	}
-
-	// 获取当前执行文件的路径
-	execPath, err := os.Executable()
-	if err != nil {
-		return fmt.Errorf("获取执行文件路径失败: %v", err)
-	}
-	execDir := filepath.Dir(execPath)
-
-	// 获取当前工作目录
-	workDir, _ := os.Getwd()
-
-	// 预先创建模板文件以确保可用
-	templateDir := filepath.Join(workDir, "scripts", "generator", "templates")
-	if err := os.MkdirAll(templateDir, 0755); err == nil {
-		tempTemplatePath := filepath.Join(templateDir, templateName)
-
-		// 检查模板文件是否存在，如果不存在则创建
-		if _, err := os.Stat(tempTemplatePath); os.IsNotExist(err) {
-			// 根据模板名创建基本内容
-			var templateContent string
-
-			if templateName == "config.tmpl" {
-				templateContent = `# 应用配置
-app:
-  name: {{.ProjectName}}
-  port: {{.ServerPort}}
-
-# 数据库配置
-database:
-  type: {{.DBType}}
-  host: {{.DBHost}}
-  port: {{.DBPort}}
-  username: {{.DBUser}}
-  password: {{.DBPassword}}
-  dbname: {{.DBName}}
-  max_idle_conns: 10
-  max_open_conns: 100
-  log_mode: true
-
-# Redis配置
-redis:
-  host: {{.RedisHost}}
-  port: {{.RedisPort}}
-  password: {{.RedisPassword}}
-  db: {{.RedisDB}}
-  pool_size: 100`
-			} else if templateName == "main.tmpl" {
-				templateContent = `package main
-
-import (
-	"fmt"
-	"log"
-	"{{.ProjectName}}/pkg/config"
-	"{{.ProjectName}}/pkg/database"
-	"{{.ProjectName}}/pkg/cache"
-	"{{.ProjectName}}/pkg/logger"
-	"{{.ProjectName}}/internal/api"
-)
-
-func main() {
-	// 初始化配置
-	cfg := config.InitConfig()
-	
-	// 初始化日志
-	logger.InitLogger()
-	
-	// 初始化数据库连接
-	db := database.InitDB()
-	
-	// 初始化Redis客户端
-	redis := cache.InitRedis()
-	
-	// 初始化路由
-	router := api.InitRouter(db, redis)
-	
-	// 启动服务器
-	port := cfg.GetString("app.port")
-	log.Printf("服务启动在 http://localhost:%s", port)
-	if err := router.Run(fmt.Sprintf(":%s", port)); err != nil {
-		log.Fatalf("启动服务失败: %v", err)
-	}
-}
-`
-			} else if templateName == "router.tmpl" {
-				templateContent = `package api
-
-import (
-	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
-	"github.com/go-redis/redis/v8"
-)
-
-// InitRouter 初始化路由
-func InitRouter(db *gorm.DB, redisClient *redis.Client) *gin.Engine {
-	r := gin.Default()
-	
-	// 中间件
-	r.Use(gin.Recovery())
-	
-	// 健康检查
-	r.GET("/ping", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"message": "pong",
-		})
-	})
-	
-	// 初始化用户相关路由
-	initUserRoutes(r, db)
-	
-	return r
-}
-
-// 初始化用户相关路由
-func initUserRoutes(r *gin.Engine, db *gorm.DB) {
-	handler := NewUserHandler(db)
-	
-	userGroup := r.Group("/api/users")
-	{
-		userGroup.POST("", handler.Create)
-		userGroup.GET("/:id", handler.GetByID)
-		userGroup.PUT("/:id", handler.Update)
-		userGroup.DELETE("/:id", handler.Delete)
-		userGroup.GET("", handler.List)
-	}
-}`
-			} else if templateName == "user_model.tmpl" {
-				templateContent = `package model
-
-import (
-	"time"
-)
-
-// User 用户模型
-type User struct {
-	ID        uint      ` + "`json:\"id\" gorm:\"primaryKey\"`" + `
-	Username  string    ` + "`json:\"username\" gorm:\"size:50;not null;unique\"`" + `
-	Password  string    ` + "`json:\"password,omitempty\" gorm:\"size:100;not null\"`" + `
-	Email     string    ` + "`json:\"email\" gorm:\"size:100;unique\"`" + `
-	CreatedAt time.Time ` + "`json:\"created_at\"`" + `
-	UpdatedAt time.Time ` + "`json:\"updated_at\"`" + `
-}
-
-// TableName 指定表名
-func (User) TableName() string {
-	return "users"
-}`
-			} else if templateName == "user_dao.tmpl" {
-				templateContent = `package dao
-
-import (
-	"gorm.io/gorm"
-	"{{.ProjectName}}/internal/model"
-)
-
-// UserDAO 用户数据访问对象
-type UserDAO struct {
-	BaseDAO
-}
-
-// NewUserDAO 创建用户DAO
-func NewUserDAO(db *gorm.DB) *UserDAO {
-	return &UserDAO{
-		BaseDAO: BaseDAO{DB: db},
-	}
-}
-
-// GetByUsername 根据用户名查找用户
-func (d *UserDAO) GetByUsername(username string) (*model.User, error) {
-	var user model.User
-	err := d.DB.Where("username = ?", username).First(&user).Error
-	if err != nil {
-		return nil, err
-	}
-	return &user, nil
-}
-
-// GetByEmail 根据邮箱查找用户
-func (d *UserDAO) GetByEmail(email string) (*model.User, error) {
-	var user model.User
-	err := d.DB.Where("email = ?", email).First(&user).Error
-	if err != nil {
-		return nil, err
-	}
-	return &user, nil
-}
-
-// FindByID 根据ID查找用户
-func (d *UserDAO) FindByID(id uint) (*model.User, error) {
-	var user model.User
-	err := d.DB.First(&user, id).Error
-	if err != nil {
-		return nil, err
-	}
-	return &user, nil
-}
-
-// ListUsers 获取用户列表
-func (d *UserDAO) ListUsers(page, pageSize int) ([]model.User, error) {
-	var users []model.User
-	offset := (page - 1) * pageSize
-	err := d.DB.Offset(offset).Limit(pageSize).Find(&users).Error
-	return users, err
-}`
-			} else if templateName == "user_service.tmpl" {
-				templateContent = `package service
-
-import (
-	"errors"
-	"golang.org/x/crypto/bcrypt"
-	"gorm.io/gorm"
-	"{{.ProjectName}}/internal/dao"
-	"{{.ProjectName}}/internal/model"
-)
-
-// UserService 用户服务
-type UserService struct {
-	BaseService
-	userDAO *dao.UserDAO
-}
-
-// NewUserService 创建用户服务
-func NewUserService(db *gorm.DB) *UserService {
-	return &UserService{
-		BaseService: BaseService{DB: db},
-		userDAO:     dao.NewUserDAO(db),
-	}
-}
-
-// CreateUser 创建用户
-func (s *UserService) CreateUser(user *model.User) error {
-	// 检查用户名是否已存在
-	existingUser, _ := s.userDAO.GetByUsername(user.Username)
-	if existingUser != nil {
-		return errors.New("用户名已存在")
-	}
-	
-	// 检查邮箱是否已存在
-	if user.Email != "" {
-		existingUser, _ = s.userDAO.GetByEmail(user.Email)
-		if existingUser != nil {
-			return errors.New("邮箱已存在")
-		}
-	}
-	
-	// 加密密码
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
-	if err != nil {
-		return err
-	}
-	user.Password = string(hashedPassword)
-	
-	return s.userDAO.Create(user)
-}
-
-// GetUserByID 根据ID获取用户
-func (s *UserService) GetUserByID(id uint) (*model.User, error) {
-	return s.userDAO.FindByID(id)
-}
-
-// UpdateUser 更新用户
-func (s *UserService) UpdateUser(user *model.User) error {
-	return s.userDAO.Update(user)
-}
-
-// DeleteUser 删除用户
-func (s *UserService) DeleteUser(id uint) error {
-	user, err := s.userDAO.FindByID(id)
-	if err != nil {
-		return err
-	}
-	return s.userDAO.Delete(user)
-}
-
-// ListUsers 获取用户列表
-func (s *UserService) ListUsers(page, pageSize int) ([]model.User, error) {
-	return s.userDAO.ListUsers(page, pageSize)
-}`
-			} else if templateName == "user_handler.tmpl" {
-				templateContent = `package api
-
-import (
-	"net/http"
-	"strconv"
-	
-	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
-	
-	"{{.ProjectName}}/internal/model"
-	"{{.ProjectName}}/internal/service"
-)
-
-// UserHandler 用户处理器
-type UserHandler struct {
-	userService *service.UserService
-}
-
-// NewUserHandler 创建用户处理器
-func NewUserHandler(db *gorm.DB) *UserHandler {
-	return &UserHandler{
-		userService: service.NewUserService(db),
-	}
-}
-
-// Create 创建用户
-func (h *UserHandler) Create(c *gin.Context) {
-	var user model.User
-	if err := c.ShouldBindJSON(&user); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-	
-	if err := h.userService.CreateUser(&user); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-	
-	// 清除密码
-	user.Password = ""
-	
-	c.JSON(http.StatusCreated, user)
-}
-
-// GetByID 根据ID获取用户
-func (h *UserHandler) GetByID(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := strconv.ParseUint(idStr, 10, 64)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的ID"})
-		return
-	}
-	
-	user, err := h.userService.GetUserByID(uint(id))
-	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "用户不存在"})
-		return
-	}
-	
-	// 清除密码
-	user.Password = ""
-	
-	c.JSON(http.StatusOK, user)
-}
-
-// Update 更新用户
-func (h *UserHandler) Update(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := strconv.ParseUint(idStr, 10, 64)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的ID"})
-		return
-	}
-	
-	// 检查用户是否存在
-	user, err := h.userService.GetUserByID(uint(id))
-	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "用户不存在"})
-		return
-	}
-	
-	// 绑定请求数据
-	var updateData model.User
-	if err := c.ShouldBindJSON(&updateData); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-	
-	// 更新用户
-	user.Username = updateData.Username
-	user.Email = updateData.Email
-	if updateData.Password != "" {
-		user.Password = updateData.Password
-	}
-	
-	if err := h.userService.UpdateUser(user); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-	
-	// 清除密码
-	user.Password = ""
-	
-	c.JSON(http.StatusOK, user)
-}
-
-// Delete 删除用户
-func (h *UserHandler) Delete(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := strconv.ParseUint(idStr, 10, 64)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "无效的ID"})
-		return
-	}
-	
-	if err := h.userService.DeleteUser(uint(id)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-	
-	c.JSON(http.StatusOK, gin.H{"message": "用户已删除"})
-}
-
-// List 获取用户列表
-func (h *UserHandler) List(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
-	
-	users, err := h.userService.ListUsers(page, pageSize)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-	
-	// 清除所有用户的密码
-	for i := range users {
-		users[i].Password = ""
-	}
-	
-	c.JSON(http.StatusOK, users)
-}`
-			} else if templateName == "wire.tmpl" {
-				templateContent = `package wire
-
-import (
-	"github.com/google/wire"
-)
-
-// InitApp 初始化应用依赖
-var InitApp = wire.NewSet(
-	ProvideDB,
-	ProvideRedis,
-	ProvideRouter,
-)`
-			} else if templateName == "wire_provider_base.tmpl" {
-				templateContent = `package wire
-
-import (
-	"github.com/gin-gonic/gin"
-	"github.com/go-redis/redis/v8"
-	"gorm.io/gorm"
-	"{{.ProjectName}}/pkg/cache"
-	"{{.ProjectName}}/pkg/database"
-	"{{.ProjectName}}/internal/api"
-)
-
-// ProvideDB 提供数据库实例
-func ProvideDB() *gorm.DB {
-	return database.InitDB()
-}
-
-// ProvideRedis 提供Redis实例
-func ProvideRedis() *redis.Client {
-	return cache.InitRedis()
-}
-
-// ProvideRouter 提供路由实例
-func ProvideRouter(db *gorm.DB, redis *redis.Client) *gin.Engine {
-	return api.InitRouter(db, redis)
-}`
-			} else {
-				// 其他模板采用简单内容
-				templateContent = fmt.Sprintf("// %s 模板文件\n// 用于 %s\n", templateName, templateName)
-			}
-
-			// 写入模板文件
-			err := os.WriteFile(tempTemplatePath, []byte(templateContent), 0644)
-			if err == nil {
-				fmt.Printf("创建临时模板文件: %s\n", tempTemplatePath)
-			}
-		}
-	}
-
-	// 读取模板文件 - 尝试多个可能的路径
-	possiblePaths := []string{
-		filepath.Join(workDir, "scripts", "generator", "templates", templateName),
-		filepath.Join(workDir, templateName),
-		templateName,
-		filepath.Join("scripts", "generator", "templates", templateName),
-		filepath.Join("templates", templateName),
-		filepath.Join(execDir, "scripts", "generator", "templates", templateName),
-		filepath.Join(execDir, "..", "scripts", "generator", "templates", templateName),
-	}
-
-	var templateContent []byte
-	var readErr error
-
-	for _, path := range possiblePaths {
-		fmt.Printf("尝试读取模板文件: %s\n", path)
-		templateContent, readErr = os.ReadFile(path)
-		if readErr == nil {
-			fmt.Printf("成功读取模板文件: %s\n", path)
-			break
-		}
-	}
-
-	if readErr != nil {
-		return fmt.Errorf("读取模板文件 %s 失败: %v\n尝试的路径: %v", templateName, readErr, possiblePaths)
-	}
-
-	// 创建文件
-	file, err := os.Create(filePath)
-	if err != nil {
-		return fmt.Errorf("创建文件 %s 失败: %v", filePath, err)
-	}
-	defer file.Close()
-
-	// 解析模板
-	tmpl, err := template.New(filepath.Base(templateName)).Parse(string(templateContent))
-	if err != nil {
-		return fmt.Errorf("解析模板失败: %v", err)
-	}
-
-	// 执行模板
-	err = tmpl.Execute(file, data)
-	if err != nil {
-		return fmt.Errorf("执行模板失败: %v", err)
-	}
-
-	return nil
-}
-
-// 从模板创建文件（传入模板字符串）
-func createFileFromTemplate(filePath, templateContent string, data interface{}) error {
-	// 创建文件
-	file, err := os.Create(filePath)
-	if err != nil {
-		return fmt.Errorf("创建文件 %s 失败: %v", filePath, err)
-	}
-	defer file.Close()
-
-	// 解析模板
-	tmpl, err := template.New(filepath.Base(filePath)).Parse(templateContent)
-	if err != nil {
-		return fmt.Errorf("解析模板失败: %v", err)
-	}
-
-	// 执行模板
-	err = tmpl.Execute(file, data)
-	if err != nil {
-		return fmt.Errorf("执行模板失败: %v", err)
-	}
-
-	return nil
-}
-
-// 获取用户输入
-func getUserInput(prompt string, defaultValue string) string {
-	reader := bufio.NewReader(os.Stdin)
-	if defaultValue != "" {
-		fmt.Printf("%s [%s]: ", prompt, defaultValue)
-	} else {
-		fmt.Printf("%s: ", prompt)
-	}
-
-	input, _ := reader.ReadString('\n')
-	input = strings.TrimSpace(input)
-
-	if input == "" {
-		return defaultValue
-	}
-	return input
-}
-
-// 创建项目的主函数
-func CreateProject() {
-	// 创建项目
-	fmt.Println("=== Go Web Quick Start 项目生成器 ===")
-	fmt.Println("请输入以下信息来生成您的项目：")
-
-	// 获取项目信息
-	config := model.ProjectConfig{}
-
-	// 项目名称
-	config.ProjectName = getUserInput("项目名称（Go模块名）", "github.com/yourusername/myproject")
-
-	// 确保项目名称格式正确
-	if !strings.Contains(config.ProjectName, "/") {
-		fmt.Println("警告: 项目名称应该是完整的模块路径，例如 github.com/username/project")
-		fmt.Println("将自动修正为标准格式...")
-		config.ProjectName = "github.com/" + config.ProjectName
-	}
-
-	// 项目路径
-	defaultPath, _ := os.Getwd()
-	config.ProjectPath = getUserInput("项目路径", defaultPath)
-
-	// 数据库类型
-	config.DBType = getUserInput("数据库类型 (mysql, postgres, sqlite, sqlserver, oracle)", "mysql")
-
-	// 数据库连接信息
-	config.DBHost = getUserInput("数据库主机", "localhost")
-	config.DBPort = getUserInput("数据库端口", "3306")
-	config.DBUser = getUserInput("数据库用户名", "root")
-	config.DBPassword = getUserInput("数据库密码", "")
-	config.DBName = getUserInput("数据库名", "mydb")
-
-	// Redis连接信息
-	config.RedisHost = getUserInput("Redis主机", "localhost")
-	config.RedisPort = getUserInput("Redis端口", "6379")
-	config.RedisPassword = getUserInput("Redis密码", "")
-	config.RedisDB = getUserInput("Redis数据库", "0")
-
-	// 服务器端口
-	config.ServerPort = getUserInput("服务器端口", "8080")
-
-	// 创建项目
-	fmt.Println("\n正在生成项目...")
-	err := createProjectStructure(config)
-	if err != nil {
-		fmt.Printf("生成项目失败: %v\n", err)
-		os.Exit(1)
-	}
 
 	fmt.Println("\n项目生成成功！")
 	fmt.Printf("项目位置: %s\n", config.ProjectPath)
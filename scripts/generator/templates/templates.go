@@ -0,0 +1,34 @@
+// Package templates 内置代码生成模板，编译时嵌入生成器二进制文件
+package templates
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+//go:embed *.tmpl
+var FS embed.FS
+
+// Load 读取模板内容
+// dir 为自定义模板目录，不为空且目录中存在同名模板时优先使用，否则使用内置模板
+func Load(dir, name string) ([]byte, error) {
+	if dir != "" {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err == nil {
+			return content, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("读取自定义模板 %s 失败: %v", filepath.Join(dir, name), err)
+		}
+	}
+
+	content, err := FS.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("内置模板 %s 不存在", name)
+	}
+	return content, nil
+}
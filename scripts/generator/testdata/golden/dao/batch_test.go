@@ -0,0 +1,151 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"example.com/golden/internal/model"
+	"gorm.io/gorm"
+)
+
+// 数据库中的全部标题，按id排序
+func allTitles(t *testing.T, db *gorm.DB) []string {
+	t.Helper()
+	var posts []model.Post
+	if err := db.Order("id").Find(&posts).Error; err != nil {
+		t.Fatal(err)
+	}
+	return titles(posts)
+}
+
+// BatchError 中失败记录的下标
+func failedIndexes(t *testing.T, err error) []int {
+	t.Helper()
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("error = %v, 期望 *BatchError", err)
+	}
+	var indexes []int
+	for i := range batchErr.Errors {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+	return indexes
+}
+
+func TestCreateBatch(t *testing.T) {
+	db := newTestDB(t)
+	seedPosts(t, db)
+	postDAO := NewPostDAO(db)
+	ctx := context.Background()
+
+	if err := postDAO.CreateBatch(ctx, nil); err != nil {
+		t.Errorf("空批量 CreateBatch() error = %v", err)
+	}
+
+	posts := []model.Post{{Title: "Kafka", Slug: "kafka"}, {Title: "Etcd", Slug: "etcd"}}
+	if err := postDAO.CreateBatch(ctx, posts); err != nil {
+		t.Fatalf("CreateBatch() error = %v", err)
+	}
+	if posts[0].ID == 0 || posts[1].ID == 0 || posts[0].ID == posts[1].ID {
+		t.Errorf("创建后应写回主键, ID = %d, %d", posts[0].ID, posts[1].ID)
+	}
+
+	// 整体执行失败后回滚，再逐条执行：只有冲突的记录失败，其余记录只创建一次
+	posts = []model.Post{
+		{Title: "Nats", Slug: "nats"},
+		{Title: "Go重复", Slug: "go"},
+		{Title: "Nats重复", Slug: "nats"},
+		{Title: "Mongo", Slug: "mongo"},
+	}
+	err := postDAO.CreateBatch(ctx, posts)
+	if got := failedIndexes(t, err); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("失败的记录 = %v, 期望 [1 2]", got)
+	}
+	if posts[0].ID == 0 || posts[3].ID == 0 || posts[1].ID != 0 || posts[2].ID != 0 {
+		t.Errorf("只有成功的记录应写回主键, ID = %d, %d, %d, %d", posts[0].ID, posts[1].ID, posts[2].ID, posts[3].ID)
+	}
+	want := []string{"Go入门", "Gin实战", "Gorm指南", "Redis", "Wire", "Kafka", "Etcd", "Nats", "Mongo"}
+	if got := allTitles(t, db); !reflect.DeepEqual(got, want) {
+		t.Errorf("批量创建后的记录 = %v, 期望 %v", got, want)
+	}
+}
+
+func TestUpsertBatch(t *testing.T) {
+	db := newTestDB(t)
+	seedPosts(t, db)
+	postDAO := NewPostDAO(db)
+
+	posts := []model.Post{{Title: "Go进阶", Slug: "go", Views: 99}, {Title: "Kafka", Slug: "kafka"}}
+	if err := postDAO.UpsertBatch(context.Background(), posts, "slug"); err != nil {
+		t.Fatalf("UpsertBatch() error = %v", err)
+	}
+	want := []string{"Go进阶", "Gin实战", "Gorm指南", "Redis", "Wire", "Kafka"}
+	if got := allTitles(t, db); !reflect.DeepEqual(got, want) {
+		t.Errorf("UpsertBatch 后的记录 = %v, 期望 %v", got, want)
+	}
+	var updated model.Post
+	if err := db.Where("slug = ?", "go").First(&updated).Error; err != nil {
+		t.Fatal(err)
+	}
+	if updated.ID != 1 || updated.Views != 99 {
+		t.Errorf("冲突时应更新已有记录, 得到 ID = %d, Views = %d", updated.ID, updated.Views)
+	}
+}
+
+func TestUpdateBatch(t *testing.T) {
+	db := newTestDB(t)
+	posts := seedPosts(t, db)
+	postDAO := NewPostDAO(db)
+
+	first, second := posts[0], posts[1]
+	first.Title = "Go入门（第二版）"
+	second.Views = 31
+	missing := posts[2]
+	missing.ID = 999
+	batch := []model.Post{first, missing, second}
+
+	err := postDAO.UpdateBatch(context.Background(), batch)
+	if got := failedIndexes(t, err); !reflect.DeepEqual(got, []int{1}) {
+		t.Fatalf("失败的记录 = %v, 期望 [1]", got)
+	}
+	var batchErr *BatchError
+	errors.As(err, &batchErr)
+	if !errors.Is(batchErr.Errors[1], gorm.ErrRecordNotFound) {
+		t.Errorf("不存在的记录的错误 = %v, 期望 gorm.ErrRecordNotFound", batchErr.Errors[1])
+	}
+
+	want := []string{"Go入门（第二版）", "Gin实战", "Gorm指南", "Redis", "Wire"}
+	if got := allTitles(t, db); !reflect.DeepEqual(got, want) {
+		t.Errorf("UpdateBatch 后的记录 = %v, 期望 %v", got, want)
+	}
+	var updated model.Post
+	if err := db.First(&updated, second.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if updated.Views != 31 {
+		t.Errorf("第三条记录的 views = %d, 期望 31", updated.Views)
+	}
+}
+
+func TestDeleteByIDs(t *testing.T) {
+	db := newTestDB(t)
+	posts := seedPosts(t, db)
+	postDAO := NewPostDAO(db)
+	ctx := context.Background()
+
+	deleted, err := postDAO.DeleteByIDs(ctx, []int{posts[0].ID, posts[2].ID, 999})
+	if err != nil || deleted != 2 {
+		t.Fatalf("DeleteByIDs() = %d, %v, 期望 2, nil", deleted, err)
+	}
+	if deleted, err := postDAO.DeleteByIDs(ctx, nil); err != nil || deleted != 0 {
+		t.Errorf("空ID列表 DeleteByIDs() = %d, %v, 期望 0, nil", deleted, err)
+	}
+	want := []string{"Gin实战", "Redis", "Wire"}
+	if got := allTitles(t, db); !reflect.DeepEqual(got, want) {
+		t.Errorf("DeleteByIDs 后的记录 = %v, 期望 %v", got, want)
+	}
+}
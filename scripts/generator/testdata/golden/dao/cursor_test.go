@@ -0,0 +1,150 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"example.com/golden/internal/model"
+)
+
+// 从第一页开始按游标逐页读取，返回所有记录和每页的记录数
+func listAllByCursor(t *testing.T, postDAO PostDAO, query url.Values) ([]model.Post, []int) {
+	t.Helper()
+	var all []model.Post
+	var pages []int
+	cursor := ""
+	for {
+		values := url.Values{}
+		for key, value := range query {
+			values[key] = value
+		}
+		values.Set("cursor", cursor)
+		spec, err := ParseQuerySpec(values, PostColumns)
+		if err != nil {
+			t.Fatalf("ParseQuerySpec(%s) error = %v", values.Encode(), err)
+		}
+		posts, next, err := postDAO.ListByCursor(context.Background(), spec)
+		if err != nil {
+			t.Fatalf("ListByCursor() error = %v", err)
+		}
+		all = append(all, posts...)
+		pages = append(pages, len(posts))
+		if next == "" {
+			return all, pages
+		}
+		if len(pages) > 10 {
+			t.Fatal("游标分页没有结束")
+		}
+		cursor = next
+	}
+}
+
+func TestListByCursor(t *testing.T) {
+	db := newTestDB(t)
+	seedPosts(t, db)
+	postDAO := NewPostDAO(db)
+
+	tests := []struct {
+		query     string
+		want      []string
+		wantPages []int
+	}{
+		{query: "page_size=2", want: []string{"Go入门", "Gin实战", "Gorm指南", "Redis", "Wire"}, wantPages: []int{2, 2, 1}},
+		// 排序列有重复值时按追加的id区分
+		{query: "sort=-views&page_size=2", want: []string{"Gin实战", "Redis", "Gorm指南", "Go入门", "Wire"}, wantPages: []int{2, 2, 1}},
+		{query: "sort=-created_at&page_size=3", want: []string{"Wire", "Redis", "Gorm指南", "Gin实战", "Go入门"}, wantPages: []int{3, 2}},
+		{query: "sort=title&page_size=5", want: []string{"Gin实战", "Gorm指南", "Go入门", "Redis", "Wire"}, wantPages: []int{5}},
+		{query: "filter[published]=true&sort=-views&page_size=1", want: []string{"Redis", "Gorm指南", "Go入门"}, wantPages: []int{1, 1, 1}},
+		// 只选择 title 时仍会查询排序列以生成游标
+		{query: "fields=title&sort=-views&page_size=2", want: []string{"Gin实战", "Redis", "Gorm指南", "Go入门", "Wire"}, wantPages: []int{2, 2, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			posts, pages := listAllByCursor(t, postDAO, query)
+			if got := titles(posts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("游标分页结果 = %v, 期望 %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(pages, tt.wantPages) {
+				t.Errorf("每页记录数 = %v, 期望 %v", pages, tt.wantPages)
+			}
+		})
+	}
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	db := newTestDB(t)
+	posts := seedPosts(t, db)
+
+	spec, err := ParseQuerySpec(url.Values{"sort": {"-created_at,title"}, "cursor": {""}}, PostColumns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cursor, err := spec.NextCursor(db, &posts[2])
+	if err != nil {
+		t.Fatalf("NextCursor() error = %v", err)
+	}
+
+	next, err := ParseQuerySpec(url.Values{"sort": {"-created_at,title"}, "cursor": {cursor}}, PostColumns)
+	if err != nil {
+		t.Fatalf("解析 NextCursor 生成的游标失败: %v", err)
+	}
+	if len(next.after) != 3 {
+		t.Fatalf("游标中的值 = %v, 期望包含 created_at、title 和 id", next.after)
+	}
+	if createdAt, ok := next.after[0].(time.Time); !ok || !createdAt.Equal(posts[2].CreatedAt) {
+		t.Errorf("游标中的 created_at = %v, 期望 %v", next.after[0], posts[2].CreatedAt)
+	}
+	if next.after[1] != posts[2].Title || next.after[2] != int64(posts[2].ID) {
+		t.Errorf("游标中的 title、id = %v, %v, 期望 %v, %v", next.after[1], next.after[2], posts[2].Title, posts[2].ID)
+	}
+}
+
+func TestInvalidCursor(t *testing.T) {
+	db := newTestDB(t)
+	posts := seedPosts(t, db)
+
+	spec, err := ParseQuerySpec(url.Values{"sort": {"-views"}, "cursor": {""}}, PostColumns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cursor, err := spec.NextCursor(db, &posts[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		values url.Values
+	}{
+		{name: "不是base64", values: url.Values{"cursor": {"!!!"}}},
+		{name: "不是JSON", values: url.Values{"cursor": {"bm90LWpzb24"}}},
+		{name: "排序条件改变", values: url.Values{"sort": {"title"}, "cursor": {cursor}}},
+		{name: "排序方向改变", values: url.Values{"sort": {"views"}, "cursor": {cursor}}},
+		{name: "值的类型不匹配", values: url.Values{"sort": {"-views"}, "cursor": {"eyJzIjoiLXZpZXdzLGlkIiwidiI6WyJ4IiwiMSJdfQ"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseQuerySpec(tt.values, PostColumns)
+			if !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("ParseQuerySpec() error = %v, 期望 ErrInvalidCursor", err)
+			}
+		})
+	}
+
+	// 游标分页不支持按包含空值的列排序
+	nullable, err := ParseQuerySpec(url.Values{"sort": {"published_at"}, "cursor": {""}}, PostColumns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := nullable.NextCursor(db, &posts[1]); err == nil {
+		t.Error("按空值列生成游标应返回错误")
+	}
+}
@@ -0,0 +1,184 @@
+package dao
+
+import (
+	"context"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseQuerySpecRejectsUnknownColumns(t *testing.T) {
+	tests := []struct {
+		query   string
+		wantErr string
+	}{
+		{query: "filter[password]=x", wantErr: "不支持按 password 过滤"},
+		{query: "filter[title)%20OR%201%3D1%20--]=x", wantErr: "不支持按 title) OR 1=1 -- 过滤"},
+		{query: "filter[views][regex]=1", wantErr: "不支持的过滤操作符 regex"},
+		{query: "filter[views][like]=1", wantErr: "views 不支持 like 条件"},
+		{query: "filter[views]=abc", wantErr: "views 的值 abc 无效"},
+		{query: "filter[views][between]=1", wantErr: "需要两个值"},
+		{query: "filter[published_at][null]=maybe", wantErr: "只能为 true 或 false"},
+		{query: "filter[views][gt][x]=1", wantErr: "无效的过滤参数"},
+		{query: "sort=-password", wantErr: "不支持按 password 排序"},
+		{query: "sort=title%3Bdrop%20table%20posts", wantErr: "不支持按 title;drop table posts 排序"},
+		{query: "fields=id,password", wantErr: "不支持选择字段 password"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = ParseQuerySpec(values, PostColumns)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseQuerySpec(%s) error = %v, 期望包含 %q", tt.query, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestListFilters(t *testing.T) {
+	db := newTestDB(t)
+	seedPosts(t, db)
+	postDAO := NewPostDAO(db)
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{query: "", want: []string{"Go入门", "Gin实战", "Gorm指南", "Redis", "Wire"}},
+		{query: "filter[views]=30", want: []string{"Gin实战", "Redis"}},
+		{query: "filter[views][ne]=30", want: []string{"Go入门", "Gorm指南", "Wire"}},
+		{query: "filter[views][lt]=20", want: []string{"Go入门", "Wire"}},
+		{query: "filter[views][lte]=20", want: []string{"Go入门", "Gorm指南", "Wire"}},
+		{query: "filter[views][gt]=20", want: []string{"Gin实战", "Redis"}},
+		{query: "filter[views][gte]=20", want: []string{"Gin实战", "Gorm指南", "Redis"}},
+		{query: "filter[slug][in]=go,redis,missing", want: []string{"Go入门", "Redis"}},
+		{query: "filter[title][like]=G", want: []string{"Go入门", "Gin实战", "Gorm指南"}},
+		{query: "filter[title][like]=%25实战", want: []string{"Gin实战"}},
+		{query: "filter[views][between]=10,20", want: []string{"Go入门", "Gorm指南"}},
+		{query: "filter[published]=true", want: []string{"Go入门", "Gorm指南", "Redis"}},
+		{query: "filter[published_at][null]=true", want: []string{"Gin实战", "Wire"}},
+		{query: "filter[published_at][null]=false", want: []string{"Go入门", "Gorm指南", "Redis"}},
+		{query: "filter[published_at][gte]=2024-02-01T00:00:00Z", want: []string{"Gorm指南", "Redis"}},
+		{query: "filter[views][gte]=20&filter[published]=true", want: []string{"Gorm指南", "Redis"}},
+		{query: "filter[views][gt]=0&filter[views][lt]=30", want: []string{"Go入门", "Gorm指南"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query + "&sort=id")
+			if err != nil {
+				t.Fatal(err)
+			}
+			spec, err := ParseQuerySpec(values, PostColumns)
+			if err != nil {
+				t.Fatalf("ParseQuerySpec() error = %v", err)
+			}
+			posts, total, err := postDAO.List(context.Background(), spec)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if got := titles(posts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("List(%s) = %v, 期望 %v", tt.query, got, tt.want)
+			}
+			if total != int64(len(tt.want)) {
+				t.Errorf("List(%s) total = %d, 期望 %d", tt.query, total, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestListSortAndPaging(t *testing.T) {
+	db := newTestDB(t)
+	seedPosts(t, db)
+	postDAO := NewPostDAO(db)
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{query: "sort=-views,title", want: []string{"Gin实战", "Redis", "Gorm指南", "Go入门", "Wire"}},
+		{query: "sort=views,-id", want: []string{"Wire", "Go入门", "Gorm指南", "Redis", "Gin实战"}},
+		{query: "sort=-views,title&page=2&page_size=2", want: []string{"Gorm指南", "Go入门"}},
+		{query: "sort=-views,title&page=3&page_size=2", want: []string{"Wire"}},
+		{query: "sort=-views,title&page=4&page_size=2", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			spec, err := ParseQuerySpec(values, PostColumns)
+			if err != nil {
+				t.Fatalf("ParseQuerySpec() error = %v", err)
+			}
+			posts, total, err := postDAO.List(context.Background(), spec)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if got := titles(posts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("List(%s) = %v, 期望 %v", tt.query, got, tt.want)
+			}
+			if total != 5 {
+				t.Errorf("分页不应影响总数, total = %d", total)
+			}
+		})
+	}
+}
+
+func TestNewQuerySpecNormalizesPaging(t *testing.T) {
+	tests := []struct {
+		page, pageSize         int
+		wantPage, wantPageSize int
+	}{
+		{page: 0, pageSize: 0, wantPage: 1, wantPageSize: DefaultPageSize},
+		{page: -1, pageSize: -5, wantPage: 1, wantPageSize: DefaultPageSize},
+		{page: 3, pageSize: 20, wantPage: 3, wantPageSize: 20},
+		{page: 1, pageSize: MaxPageSize + 1, wantPage: 1, wantPageSize: MaxPageSize},
+	}
+	for _, tt := range tests {
+		spec := NewQuerySpec(tt.page, tt.pageSize)
+		if spec.Page != tt.wantPage || spec.PageSize != tt.wantPageSize {
+			t.Errorf("NewQuerySpec(%d, %d) = %d, %d, 期望 %d, %d", tt.page, tt.pageSize, spec.Page, spec.PageSize, tt.wantPage, tt.wantPageSize)
+		}
+	}
+}
+
+func TestProjectFields(t *testing.T) {
+	db := newTestDB(t)
+	seedPosts(t, db)
+	postDAO := NewPostDAO(db)
+
+	spec, err := ParseQuerySpec(url.Values{"fields": {"id,title"}, "sort": {"id"}, "page_size": {"2"}}, PostColumns)
+	if err != nil {
+		t.Fatalf("ParseQuerySpec() error = %v", err)
+	}
+	posts, _, err := postDAO.List(context.Background(), spec)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if posts[0].Slug != "" || posts[0].Views != 0 {
+		t.Errorf("只应查询选择的列, 第一条记录为 %+v", posts[0])
+	}
+
+	projected, err := Project(spec, posts)
+	if err != nil {
+		t.Fatalf("Project() error = %v", err)
+	}
+	want := []map[string]interface{}{
+		{"id": posts[0].ID, "title": "Go入门"},
+		{"id": posts[1].ID, "title": "Gin实战"},
+	}
+	if !reflect.DeepEqual(projected, want) {
+		t.Errorf("Project() = %#v, 期望 %#v", projected, want)
+	}
+
+	// 未选择字段时原样返回
+	all := NewQuerySpec(1, 10)
+	if got, err := Project(all, posts); err != nil || !reflect.DeepEqual(got, posts) {
+		t.Errorf("未选择字段时 Project() = %v, %v, 期望原样返回", got, err)
+	}
+}
@@ -0,0 +1,71 @@
+package dao
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"example.com/golden/internal/model"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// 打开临时目录中的SQLite数据库并创建posts表
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("打开数据库失败: %v", err)
+	}
+	if err := db.AutoMigrate(&model.Post{}); err != nil {
+		t.Fatalf("创建表失败: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+// 创建以下记录，ID依次为1到5，创建时间依次递增
+//
+//	title    slug    views  published  published_at
+//	Go入门   go      10     true       2024-01-01
+//	Gin实战  gin     30     false      null
+//	Gorm指南 gorm    20     true       2024-03-01
+//	Redis    redis   30     true       2024-02-01
+//	Wire     wire    0      false      null
+func seedPosts(t *testing.T, db *gorm.DB) []model.Post {
+	t.Helper()
+	date := func(month time.Month) *time.Time {
+		value := time.Date(2024, month, 1, 0, 0, 0, 0, time.UTC)
+		return &value
+	}
+	posts := []model.Post{
+		{Title: "Go入门", Slug: "go", Views: 10, Published: true, PublishedAt: date(1)},
+		{Title: "Gin实战", Slug: "gin", Views: 30},
+		{Title: "Gorm指南", Slug: "gorm", Views: 20, Published: true, PublishedAt: date(3)},
+		{Title: "Redis", Slug: "redis", Views: 30, Published: true, PublishedAt: date(2)},
+		{Title: "Wire", Slug: "wire"},
+	}
+	base := time.Date(2024, 6, 1, 8, 0, 0, 0, time.UTC)
+	for i := range posts {
+		posts[i].CreatedAt = base.Add(time.Duration(i) * time.Minute)
+		if err := db.Create(&posts[i]).Error; err != nil {
+			t.Fatalf("创建记录失败: %v", err)
+		}
+	}
+	return posts
+}
+
+func titles(posts []model.Post) []string {
+	result := make([]string, len(posts))
+	for i, post := range posts {
+		result[i] = post.Title
+	}
+	return result
+}
@@ -0,0 +1,121 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"example.com/golden/internal/model"
+	"gorm.io/gorm"
+)
+
+func TestUpdateChecksVersion(t *testing.T) {
+	db := newTestDB(t)
+	postDAO := NewPostDAO(db)
+	ctx := context.Background()
+
+	post := &model.Post{Title: "Go入门", Slug: "go"}
+	if err := postDAO.Create(ctx, post); err != nil {
+		t.Fatal(err)
+	}
+	if post.Version != 1 {
+		t.Fatalf("新建记录的版本号 = %d, 期望 1", post.Version)
+	}
+	stale := *post
+
+	post.Title = "Go入门（第二版）"
+	if err := postDAO.Update(ctx, post); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if post.Version != 2 {
+		t.Errorf("更新后的版本号 = %d, 期望 2", post.Version)
+	}
+
+	// 使用读取时的旧版本号更新
+	stale.Title = "覆盖"
+	err := postDAO.Update(ctx, &stale)
+	var conflict *VersionConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("旧版本号更新 error = %v, 期望 *VersionConflictError", err)
+	}
+	if conflict.Version != 1 || conflict.CurrentVersion != 2 || conflict.ConflictVersion() != 2 {
+		t.Errorf("冲突信息 = %+v, 期望提交的版本为1、当前版本为2", conflict)
+	}
+	if stale.Version != 1 {
+		t.Errorf("更新失败后版本号应恢复为 1, 实际为 %d", stale.Version)
+	}
+
+	current, err := postDAO.GetByID(ctx, post.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current.Title != "Go入门（第二版）" || current.Version != 2 {
+		t.Errorf("冲突后的记录 = %q 版本 %d, 期望保持 %q 版本 2", current.Title, current.Version, "Go入门（第二版）")
+	}
+
+	missing := *post
+	missing.ID = 999
+	if err := postDAO.Update(ctx, &missing); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("更新不存在的记录 error = %v, 期望 gorm.ErrRecordNotFound", err)
+	}
+}
+
+func TestUpdateBatchChecksVersion(t *testing.T) {
+	db := newTestDB(t)
+	posts := seedPosts(t, db)
+	postDAO := NewPostDAO(db)
+	ctx := context.Background()
+
+	for _, post := range posts {
+		if post.Version != 1 {
+			t.Fatalf("新建记录的版本号 = %d, 期望 1", post.Version)
+		}
+	}
+	if err := db.Model(&model.Post{}).Where("id = ?", posts[1].ID).Update("version", 5).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	batch := []model.Post{posts[0], posts[1]}
+	batch[0].Views = 11
+	batch[1].Views = 31
+	err := postDAO.UpdateBatch(ctx, batch)
+	if got := failedIndexes(t, err); len(got) != 1 || got[0] != 1 {
+		t.Fatalf("失败的记录 = %v, 期望 [1]", got)
+	}
+	var batchErr *BatchError
+	errors.As(err, &batchErr)
+	var conflict *VersionConflictError
+	if !errors.As(batchErr.Errors[1], &conflict) || conflict.CurrentVersion != 5 {
+		t.Errorf("版本号不一致的记录 error = %v, 期望当前版本为5的 *VersionConflictError", batchErr.Errors[1])
+	}
+	if batch[0].Version != 2 {
+		t.Errorf("更新成功的记录版本号 = %d, 期望 2", batch[0].Version)
+	}
+}
+
+func TestETag(t *testing.T) {
+	if got := FormatETag(3); got != `"3"` {
+		t.Errorf("FormatETag(3) = %s, 期望 \"3\"", got)
+	}
+
+	tests := []struct {
+		header  string
+		want    uint
+		wantOK  bool
+		wantErr bool
+	}{
+		{header: `"3"`, want: 3, wantOK: true},
+		{header: ` W/"12" `, want: 12, wantOK: true},
+		{header: ""},
+		{header: "*"},
+		{header: `"0"`, wantErr: true},
+		{header: `"abc"`, wantErr: true},
+		{header: "3", wantErr: true},
+	}
+	for _, tt := range tests {
+		version, ok, err := ParseETag(tt.header)
+		if version != tt.want || ok != tt.wantOK || (err != nil) != tt.wantErr {
+			t.Errorf("ParseETag(%q) = %d, %v, %v, 期望 %d, %v, 错误 %v", tt.header, version, ok, err, tt.want, tt.wantOK, tt.wantErr)
+		}
+	}
+}